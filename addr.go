@@ -0,0 +1,153 @@
+package ethernet
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"net"
+)
+
+const (
+	// addrMulticast is the I/G (individual/group) bit of the first octet of
+	// a hardware address. If set, the address is a multicast address.
+	addrMulticast = 0x01
+
+	// addrLocal is the U/L (universal/local) bit of the first octet of a
+	// hardware address. If set, the address is locally administered.
+	addrLocal = 0x02
+)
+
+// Well-known multicast hardware addresses used by layer 2 protocols. These
+// addresses are in the range reserved by IEEE 802.1Q, so Frames sent to them
+// are not forwarded by bridges. See Frame.IsReservedMulticast.
+var (
+	// MulticastSTP is the bridge group address to which Spanning Tree
+	// Protocol BPDUs are sent.
+	MulticastSTP = net.HardwareAddr{0x01, 0x80, 0xc2, 0x00, 0x00, 0x00}
+
+	// MulticastMACControl is the address to which MAC Control Frames, such as
+	// PAUSE and Priority Flow Control Frames, and Slow Protocols Frames,
+	// such as LACP, are sent.
+	MulticastMACControl = net.HardwareAddr{0x01, 0x80, 0xc2, 0x00, 0x00, 0x01}
+
+	// MulticastLLDP is the nearest bridge address to which Link Layer
+	// Discovery Protocol Frames are sent.
+	MulticastLLDP = net.HardwareAddr{0x01, 0x80, 0xc2, 0x00, 0x00, 0x0e}
+)
+
+// IPv4MulticastMAC returns the multicast hardware address to which Frames
+// carrying IPv4 multicast packets for ip are sent: 01:00:5e followed by the
+// low 23 bits of ip, as specified by RFC 1112. If ip is not an IPv4 multicast
+// address, IPv4MulticastMAC returns nil.
+func IPv4MulticastMAC(ip net.IP) net.HardwareAddr {
+	ip4 := ip.To4()
+	if ip4 == nil || !ip4.IsMulticast() {
+		return nil
+	}
+
+	return net.HardwareAddr{0x01, 0x00, 0x5e, ip4[1] & 0x7f, ip4[2], ip4[3]}
+}
+
+// IPv6MulticastMAC returns the multicast hardware address to which Frames
+// carrying IPv6 multicast packets for ip are sent: 33:33 followed by the low
+// 32 bits of ip, as specified by RFC 2464. If ip is not an IPv6 multicast
+// address, IPv6MulticastMAC returns nil.
+func IPv6MulticastMAC(ip net.IP) net.HardwareAddr {
+	if len(ip) != net.IPv6len || ip.To4() != nil || !ip.IsMulticast() {
+		return nil
+	}
+
+	return net.HardwareAddr{0x33, 0x33, ip[12], ip[13], ip[14], ip[15]}
+}
+
+// RandomHardwareAddr generates a random 6-byte hardware address using a
+// cryptographically secure random number generator.
+//
+// If local is true, the U/L bit is set, indicating a locally administered
+// address. If multicast is true, the I/G bit is set, indicating a multicast
+// address. Otherwise, those bits are cleared.
+//
+// RandomHardwareAddr panics if the system's random number generator fails.
+// Use ReadHardwareAddr to generate addresses from a seedable source.
+func RandomHardwareAddr(local, multicast bool) net.HardwareAddr {
+	addr, err := ReadHardwareAddr(rand.Reader, local, multicast)
+	if err != nil {
+		panic("ethernet: failed to generate random hardware address: " + err.Error())
+	}
+
+	return addr
+}
+
+// ReadHardwareAddr generates a 6-byte hardware address by reading random
+// bytes from r, and setting or clearing the U/L and I/G bits as described
+// in RandomHardwareAddr.
+//
+// A *math/rand.Rand may be used as r to generate a reproducible sequence of
+// addresses from a seed.
+func ReadHardwareAddr(r io.Reader, local, multicast bool) (net.HardwareAddr, error) {
+	addr := make(net.HardwareAddr, 6)
+	if _, err := io.ReadFull(r, addr); err != nil {
+		return nil, err
+	}
+
+	addr[0] &^= addrLocal | addrMulticast
+	if local {
+		addr[0] |= addrLocal
+	}
+	if multicast {
+		addr[0] |= addrMulticast
+	}
+
+	return addr, nil
+}
+
+// HasValidDestination reports whether a Frame's destination hardware
+// address is valid: that is, a 6-byte address which is not all zeros.
+// An all-zero or missing destination usually indicates an uninitialized
+// address.
+func (f *Frame) HasValidDestination() bool {
+	if len(f.Destination) != 6 {
+		return false
+	}
+
+	for _, b := range f.Destination {
+		if b != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsFor reports whether a Frame should be accepted by a station with the
+// hardware address addr: that is, whether its destination is addr, or the
+// Broadcast address. Multicast destinations are not accepted, because
+// subscriptions are the responsibility of the caller.
+//
+// A destination which is not 6 bytes in length is never accepted, so an
+// empty or truncated addr does not match an empty or truncated destination.
+func (f *Frame) IsFor(addr net.HardwareAddr) bool {
+	if len(f.Destination) != 6 {
+		return false
+	}
+
+	return bytes.Equal(f.Destination, addr) || bytes.Equal(f.Destination, Broadcast)
+}
+
+// IsLoopback reports whether a Frame's source and destination hardware
+// addresses are identical and not empty. Such a Frame is usually the result
+// of a misconfiguration, or indicates a loopback test.
+func (f *Frame) IsLoopback() bool {
+	return len(f.Source) > 0 && bytes.Equal(f.Source, f.Destination)
+}
+
+// IsReservedMulticast reports whether a Frame's destination hardware address
+// is in the range 01:80:c2:00:00:00 to 01:80:c2:00:00:0f, which IEEE 802.1Q
+// reserves for layer 2 control protocols such as STP, LACP, and LLDP. A
+// bridge must not forward Frames sent to these addresses.
+func (f *Frame) IsReservedMulticast() bool {
+	d := f.Destination
+	return len(d) == 6 &&
+		d[0] == 0x01 && d[1] == 0x80 && d[2] == 0xc2 &&
+		d[3] == 0x00 && d[4] == 0x00 && d[5] <= 0x0f
+}
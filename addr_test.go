@@ -0,0 +1,356 @@
+package ethernet
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"net"
+	"testing"
+)
+
+func TestRandomHardwareAddr(t *testing.T) {
+	var tests = []struct {
+		desc      string
+		local     bool
+		multicast bool
+	}{
+		{
+			desc: "universal, unicast",
+		},
+		{
+			desc:  "local, unicast",
+			local: true,
+		},
+		{
+			desc:      "universal, multicast",
+			multicast: true,
+		},
+		{
+			desc:      "local, multicast",
+			local:     true,
+			multicast: true,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			// Try several addresses, so that the bits are not correct
+			// purely by chance.
+			for j := 0; j < 32; j++ {
+				addr := RandomHardwareAddr(tt.local, tt.multicast)
+				if want, got := 6, len(addr); want != got {
+					t.Fatalf("[%02d] test %q, unexpected address length: %v != %v",
+						i, tt.desc, want, got)
+				}
+
+				if want, got := tt.local, addr[0]&0x02 != 0; want != got {
+					t.Fatalf("[%02d] test %q, unexpected U/L bit in %v: %v != %v",
+						i, tt.desc, addr, want, got)
+				}
+
+				if want, got := tt.multicast, addr[0]&0x01 != 0; want != got {
+					t.Fatalf("[%02d] test %q, unexpected I/G bit in %v: %v != %v",
+						i, tt.desc, addr, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestReadHardwareAddr(t *testing.T) {
+	// Identical seeds must produce identical addresses.
+	a, err := ReadHardwareAddr(rand.New(rand.NewSource(1)), true, false)
+	if err != nil {
+		t.Fatalf("failed to read address: %v", err)
+	}
+
+	b, err := ReadHardwareAddr(rand.New(rand.NewSource(1)), true, false)
+	if err != nil {
+		t.Fatalf("failed to read address: %v", err)
+	}
+
+	if !bytes.Equal(a, b) {
+		t.Fatalf("addresses from identical seeds differ: %v != %v", a, b)
+	}
+
+	if _, err := ReadHardwareAddr(bytes.NewReader([]byte{0, 1, 2}), false, false); err != io.ErrUnexpectedEOF {
+		t.Fatalf("unexpected error: %v != %v", io.ErrUnexpectedEOF, err)
+	}
+}
+
+func TestFrameHasValidDestination(t *testing.T) {
+	var tests = []struct {
+		desc string
+		addr net.HardwareAddr
+		ok   bool
+	}{
+		{
+			desc: "nil",
+		},
+		{
+			desc: "short",
+			addr: net.HardwareAddr{0, 1, 0},
+		},
+		{
+			desc: "all zeros",
+			addr: net.HardwareAddr{0, 0, 0, 0, 0, 0},
+		},
+		{
+			desc: "unicast",
+			addr: net.HardwareAddr{0, 0, 0, 0, 0, 1},
+			ok:   true,
+		},
+		{
+			desc: "broadcast",
+			addr: Broadcast,
+			ok:   true,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f := &Frame{Destination: tt.addr}
+			if want, got := tt.ok, f.HasValidDestination(); want != got {
+				t.Fatalf("[%02d] test %q, unexpected validity: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameIsFor(t *testing.T) {
+	station := net.HardwareAddr{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33}
+
+	var tests = []struct {
+		desc string
+		dst  net.HardwareAddr
+		addr net.HardwareAddr
+		ok   bool
+	}{
+		{
+			desc: "empty",
+		},
+		{
+			desc: "short",
+			dst:  net.HardwareAddr{0x00, 0x16, 0x3e},
+			addr: net.HardwareAddr{0x00, 0x16, 0x3e},
+		},
+		{
+			desc: "empty address",
+			dst:  station,
+		},
+		{
+			desc: "other station",
+			dst:  net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+			addr: station,
+		},
+		{
+			desc: "multicast",
+			dst:  MulticastLLDP,
+			addr: station,
+		},
+		{
+			desc: "station",
+			dst:  station,
+			addr: station,
+			ok:   true,
+		},
+		{
+			desc: "broadcast",
+			dst:  Broadcast,
+			addr: station,
+			ok:   true,
+		},
+		{
+			desc: "broadcast, empty address",
+			dst:  Broadcast,
+			ok:   true,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f := &Frame{Destination: tt.dst}
+			if want, got := tt.ok, f.IsFor(tt.addr); want != got {
+				t.Fatalf("[%02d] test %q, unexpected IsFor: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameIsLoopback(t *testing.T) {
+	var tests = []struct {
+		desc string
+		f    *Frame
+		ok   bool
+	}{
+		{
+			desc: "empty",
+			f:    &Frame{},
+		},
+		{
+			desc: "different",
+			f: &Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+			},
+		},
+		{
+			desc: "identical",
+			f: &Frame{
+				Destination: net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+			},
+			ok: true,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if want, got := tt.ok, tt.f.IsLoopback(); want != got {
+				t.Fatalf("[%02d] test %q, unexpected IsLoopback: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameIsReservedMulticast(t *testing.T) {
+	var tests = []struct {
+		desc string
+		addr net.HardwareAddr
+		ok   bool
+	}{
+		{
+			desc: "nil",
+		},
+		{
+			desc: "broadcast",
+			addr: Broadcast,
+		},
+		{
+			desc: "STP",
+			addr: net.HardwareAddr{0x01, 0x80, 0xc2, 0x00, 0x00, 0x00},
+			ok:   true,
+		},
+		{
+			desc: "LLDP",
+			addr: net.HardwareAddr{0x01, 0x80, 0xc2, 0x00, 0x00, 0x0e},
+			ok:   true,
+		},
+		{
+			desc: "end of range",
+			addr: net.HardwareAddr{0x01, 0x80, 0xc2, 0x00, 0x00, 0x0f},
+			ok:   true,
+		},
+		{
+			desc: "past end of range",
+			addr: net.HardwareAddr{0x01, 0x80, 0xc2, 0x00, 0x00, 0x10},
+		},
+		{
+			desc: "different prefix",
+			addr: net.HardwareAddr{0x01, 0x80, 0xc2, 0x00, 0x01, 0x00},
+		},
+		{
+			desc: "short",
+			addr: net.HardwareAddr{0x01, 0x80, 0xc2, 0x00, 0x00},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f := &Frame{Destination: tt.addr}
+			if want, got := tt.ok, f.IsReservedMulticast(); want != got {
+				t.Fatalf("[%02d] test %q, unexpected IsReservedMulticast: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestIPv4MulticastMAC(t *testing.T) {
+	var tests = []struct {
+		desc string
+		ip   net.IP
+		addr net.HardwareAddr
+	}{
+		{
+			desc: "nil",
+		},
+		{
+			desc: "IPv4 unicast",
+			ip:   net.IPv4(192, 0, 2, 1),
+		},
+		{
+			desc: "IPv6 multicast",
+			ip:   net.ParseIP("ff02::1"),
+		},
+		{
+			desc: "all systems",
+			ip:   net.IPv4(224, 0, 0, 1),
+			addr: net.HardwareAddr{0x01, 0x00, 0x5e, 0x00, 0x00, 0x01},
+		},
+		{
+			desc: "high bit of second octet discarded",
+			ip:   net.IPv4(239, 255, 1, 2).To4(),
+			addr: net.HardwareAddr{0x01, 0x00, 0x5e, 0x7f, 0x01, 0x02},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if want, got := tt.addr, IPv4MulticastMAC(tt.ip); !bytes.Equal(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected hardware address: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestIPv6MulticastMAC(t *testing.T) {
+	var tests = []struct {
+		desc string
+		ip   net.IP
+		addr net.HardwareAddr
+	}{
+		{
+			desc: "nil",
+		},
+		{
+			desc: "IPv6 unicast",
+			ip:   net.ParseIP("2001:db8::1"),
+		},
+		{
+			desc: "IPv4 multicast",
+			ip:   net.IPv4(224, 0, 0, 1),
+		},
+		{
+			desc: "all nodes",
+			ip:   net.ParseIP("ff02::1"),
+			addr: net.HardwareAddr{0x33, 0x33, 0x00, 0x00, 0x00, 0x01},
+		},
+		{
+			desc: "solicited node",
+			ip:   net.ParseIP("ff02::1:ff12:3456"),
+			addr: net.HardwareAddr{0x33, 0x33, 0xff, 0x12, 0x34, 0x56},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if want, got := tt.addr, IPv6MulticastMAC(tt.ip); !bytes.Equal(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected hardware address: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestMulticastAddrsReserved(t *testing.T) {
+	for _, addr := range []net.HardwareAddr{MulticastSTP, MulticastMACControl, MulticastLLDP} {
+		if !(&Frame{Destination: addr}).IsReservedMulticast() {
+			t.Fatalf("expected %v to be a reserved multicast address", addr)
+		}
+	}
+}
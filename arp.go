@@ -0,0 +1,256 @@
+package ethernet
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+)
+
+const (
+	// arpHeaderLen is the length of the fixed portion of an ARP packet, which
+	// precedes its variable length addresses.
+	arpHeaderLen = 8
+
+	// ARPHardwareTypeEthernet is the ARP hardware type for Ethernet.
+	ARPHardwareTypeEthernet = 1
+
+	// ARPOperationRequest and ARPOperationReply are the ARP operations which
+	// request a hardware address, and reply with one.
+	ARPOperationRequest = 1
+	ARPOperationReply   = 2
+)
+
+var (
+	// ErrInvalidARP is returned when an ARP packet is invalid, such as when
+	// its hardware or protocol address lengths do not match its addresses.
+	ErrInvalidARP = errors.New("invalid ARP packet")
+)
+
+// Compile-time assertions that ARP implements the binary encoding
+// interfaces.
+var (
+	_ encoding.BinaryMarshaler   = (*ARP)(nil)
+	_ encoding.BinaryUnmarshaler = (*ARP)(nil)
+)
+
+// An ARP is an Address Resolution Protocol packet, as described in RFC 826,
+// which is carried in the payload of a Frame with EtherType EtherTypeARP.
+type ARP struct {
+	// HardwareType specifies the type of hardware address, such as
+	// ARPHardwareTypeEthernet.
+	HardwareType uint16
+
+	// ProtocolType specifies the type of protocol address, using the
+	// EtherType of the protocol, such as 0x0800 for IPv4.
+	ProtocolType uint16
+
+	// HardwareLen and ProtocolLen specify the length in bytes of each
+	// hardware address and protocol address.
+	HardwareLen uint8
+	ProtocolLen uint8
+
+	// Operation specifies the ARP operation, such as ARPOperationRequest.
+	Operation uint16
+
+	// SenderHardwareAddr and SenderProtocolAddr specify the addresses of the
+	// sender of the ARP packet.
+	SenderHardwareAddr net.HardwareAddr
+	SenderProtocolAddr net.IP
+
+	// TargetHardwareAddr and TargetProtocolAddr specify the addresses of the
+	// target of the ARP packet.
+	TargetHardwareAddr net.HardwareAddr
+	TargetProtocolAddr net.IP
+}
+
+// MarshalBinary allocates a byte slice and marshals an ARP into binary form.
+// An IPv4 protocol address may be in either its 4 or 16 byte form when
+// ProtocolLen is 4.
+//
+// If the length of any hardware address does not match HardwareLen, or the
+// length of any protocol address does not match ProtocolLen, an error
+// wrapping ErrInvalidARP is returned.
+func (a *ARP) MarshalBinary() ([]byte, error) {
+	sha, err := a.hardwareAddr("sender", a.SenderHardwareAddr)
+	if err != nil {
+		return nil, err
+	}
+	spa, err := a.protocolAddr("sender", a.SenderProtocolAddr)
+	if err != nil {
+		return nil, err
+	}
+	tha, err := a.hardwareAddr("target", a.TargetHardwareAddr)
+	if err != nil {
+		return nil, err
+	}
+	tpa, err := a.protocolAddr("target", a.TargetProtocolAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, a.length())
+	binary.BigEndian.PutUint16(b[0:2], a.HardwareType)
+	binary.BigEndian.PutUint16(b[2:4], a.ProtocolType)
+	b[4] = a.HardwareLen
+	b[5] = a.ProtocolLen
+	binary.BigEndian.PutUint16(b[6:8], a.Operation)
+
+	n := arpHeaderLen
+	n += copy(b[n:], sha)
+	n += copy(b[n:], spa)
+	n += copy(b[n:], tha)
+	copy(b[n:], tpa)
+
+	return b, nil
+}
+
+// length returns the length of an ARP's binary form, as determined by its
+// hardware and protocol address lengths.
+func (a *ARP) length() int {
+	return arpHeaderLen + 2*(int(a.HardwareLen)+int(a.ProtocolLen))
+}
+
+// hardwareAddr validates the length of a hardware address for MarshalBinary.
+func (a *ARP) hardwareAddr(name string, addr net.HardwareAddr) (net.HardwareAddr, error) {
+	if len(addr) != int(a.HardwareLen) {
+		return nil, fmt.Errorf("%w: %s hardware address is %d bytes, but hardware length is %d",
+			ErrInvalidARP, name, len(addr), a.HardwareLen)
+	}
+
+	return addr, nil
+}
+
+// protocolAddr validates the length of a protocol address for MarshalBinary,
+// and converts an IPv4 address to its 4 byte form if needed.
+func (a *ARP) protocolAddr(name string, addr net.IP) (net.IP, error) {
+	if a.ProtocolLen == net.IPv4len {
+		if ip4 := addr.To4(); ip4 != nil {
+			addr = ip4
+		}
+	}
+
+	if len(addr) != int(a.ProtocolLen) {
+		return nil, fmt.Errorf("%w: %s protocol address is %d bytes, but protocol length is %d",
+			ErrInvalidARP, name, len(addr), a.ProtocolLen)
+	}
+
+	return addr, nil
+}
+
+// UnmarshalBinary unmarshals a byte slice into an ARP. Any bytes which follow
+// the ARP packet, such as the padding of a Frame's payload, are ignored. The
+// addresses of the ARP are copied, and do not alias b.
+//
+// If the byte slice does not contain enough data to unmarshal a valid ARP,
+// io.ErrUnexpectedEOF is returned.
+//
+// If the hardware or protocol address length is 0, or the hardware address
+// length is not 6 for ARPHardwareTypeEthernet, or the protocol address length
+// is not 4 for IPv4, an error wrapping ErrInvalidARP is returned.
+func (a *ARP) UnmarshalBinary(b []byte) error {
+	if len(b) < arpHeaderLen {
+		return io.ErrUnexpectedEOF
+	}
+
+	htype := binary.BigEndian.Uint16(b[0:2])
+	ptype := binary.BigEndian.Uint16(b[2:4])
+	hlen, plen := b[4], b[5]
+
+	if hlen == 0 || plen == 0 {
+		return fmt.Errorf("%w: hardware length %d, protocol length %d",
+			ErrInvalidARP, hlen, plen)
+	}
+	if htype == ARPHardwareTypeEthernet && hlen != 6 {
+		return fmt.Errorf("%w: hardware length %d for Ethernet", ErrInvalidARP, hlen)
+	}
+	if EtherType(ptype) == EtherTypeIPv4 && plen != net.IPv4len {
+		return fmt.Errorf("%w: protocol length %d for IPv4", ErrInvalidARP, plen)
+	}
+
+	a.HardwareType = htype
+	a.ProtocolType = ptype
+	a.HardwareLen = hlen
+	a.ProtocolLen = plen
+
+	if len(b) < a.length() {
+		return io.ErrUnexpectedEOF
+	}
+
+	a.Operation = binary.BigEndian.Uint16(b[6:8])
+
+	h, p := int(hlen), int(plen)
+	n := arpHeaderLen
+	a.SenderHardwareAddr = cloneBytes(b[n : n+h])
+	n += h
+	a.SenderProtocolAddr = cloneBytes(b[n : n+p])
+	n += p
+	a.TargetHardwareAddr = cloneBytes(b[n : n+h])
+	n += h
+	a.TargetProtocolAddr = cloneBytes(b[n : n+p])
+
+	return nil
+}
+
+// ARP decodes the payload of a Frame with EtherType EtherTypeARP into an ARP.
+//
+// If f does not have EtherType EtherTypeARP, an error wrapping ErrInvalidARP
+// is returned. ARP otherwise returns the same errors as ARP.UnmarshalBinary.
+func (f *Frame) ARP() (*ARP, error) {
+	if f.EtherType != EtherTypeARP {
+		return nil, fmt.Errorf("%w: EtherType %v", ErrInvalidARP, f.EtherType)
+	}
+
+	a := new(ARP)
+	if err := a.UnmarshalBinary(f.Payload); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// NewGratuitousARP creates a gratuitous ARP Frame, which announces that the
+// IPv4 address ip is in use by the hardware address mac, such as when an IP
+// address is taken over during failover. The Frame is sent from mac to the
+// broadcast address, and contains an ARP request whose sender and target
+// protocol addresses are both ip, and whose target hardware address is
+// zero, as described in RFC 5227 for ARP announcements.
+//
+// If mac is not a 6 byte hardware address, or ip is not an IPv4 address, an
+// error wrapping ErrInvalidARP is returned.
+func NewGratuitousARP(mac net.HardwareAddr, ip net.IP) (*Frame, error) {
+	if len(mac) != 6 {
+		return nil, fmt.Errorf("%w: hardware address %v is not 6 bytes", ErrInvalidARP, mac)
+	}
+
+	ip4 := ip.To4()
+	if ip4 == nil {
+		return nil, fmt.Errorf("%w: %v is not an IPv4 address", ErrInvalidARP, ip)
+	}
+
+	a := &ARP{
+		HardwareType:       ARPHardwareTypeEthernet,
+		ProtocolType:       uint16(EtherTypeIPv4),
+		HardwareLen:        6,
+		ProtocolLen:        net.IPv4len,
+		Operation:          ARPOperationRequest,
+		SenderHardwareAddr: mac,
+		SenderProtocolAddr: ip4,
+		TargetHardwareAddr: make(net.HardwareAddr, 6),
+		TargetProtocolAddr: ip4,
+	}
+
+	p, err := a.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Frame{
+		Destination: cloneBytes(Broadcast),
+		Source:      cloneBytes(mac),
+		EtherType:   EtherTypeARP,
+		Payload:     p,
+	}, nil
+}
@@ -0,0 +1,359 @@
+package ethernet
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+)
+
+// arpRequest is the binary form of an ARP request from 192.168.1.1 at
+// de:ad:be:ef:de:ad for 192.168.1.2.
+var arpRequest = []byte{
+	0x00, 0x01,
+	0x08, 0x00,
+	0x06, 0x04,
+	0x00, 0x01,
+	0xde, 0xad, 0xbe, 0xef, 0xde, 0xad,
+	192, 168, 1, 1,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	192, 168, 1, 2,
+}
+
+func TestARPMarshalBinary(t *testing.T) {
+	var tests = []struct {
+		desc string
+		a    *ARP
+		b    []byte
+		msg  string
+	}{
+		{
+			desc: "sender hardware address too short",
+			a: &ARP{
+				HardwareType:       ARPHardwareTypeEthernet,
+				ProtocolType:       uint16(EtherTypeIPv4),
+				HardwareLen:        6,
+				ProtocolLen:        4,
+				SenderHardwareAddr: net.HardwareAddr{0xde, 0xad},
+			},
+			msg: "invalid ARP packet: sender hardware address is 2 bytes, but hardware length is 6",
+		},
+		{
+			desc: "target protocol address is IPv6",
+			a: &ARP{
+				HardwareType:       ARPHardwareTypeEthernet,
+				ProtocolType:       uint16(EtherTypeIPv4),
+				HardwareLen:        6,
+				ProtocolLen:        4,
+				SenderHardwareAddr: net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+				SenderProtocolAddr: net.IPv4(192, 168, 1, 1),
+				TargetHardwareAddr: make(net.HardwareAddr, 6),
+				TargetProtocolAddr: net.ParseIP("2001:db8::1"),
+			},
+			msg: "invalid ARP packet: target protocol address is 16 bytes, but protocol length is 4",
+		},
+		{
+			desc: "OK, 16 byte IPv4 addresses",
+			a: &ARP{
+				HardwareType:       ARPHardwareTypeEthernet,
+				ProtocolType:       uint16(EtherTypeIPv4),
+				HardwareLen:        6,
+				ProtocolLen:        4,
+				Operation:          ARPOperationRequest,
+				SenderHardwareAddr: net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+				SenderProtocolAddr: net.IPv4(192, 168, 1, 1),
+				TargetHardwareAddr: make(net.HardwareAddr, 6),
+				TargetProtocolAddr: net.IPv4(192, 168, 1, 2),
+			},
+			b: arpRequest,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			b, err := tt.a.MarshalBinary()
+			if err != nil {
+				if !errors.Is(err, ErrInvalidARP) {
+					t.Fatalf("[%02d] test %q, unexpected error: %v",
+						i, tt.desc, err)
+				}
+
+				if want, got := tt.msg, err.Error(); want != got {
+					t.Fatalf("[%02d] test %q, unexpected error message: %q != %q",
+						i, tt.desc, want, got)
+				}
+				return
+			}
+			if tt.msg != "" {
+				t.Fatalf("[%02d] test %q, expected error %q", i, tt.desc, tt.msg)
+			}
+
+			if want, got := tt.b, b; !bytes.Equal(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected ARP bytes:\n- want: %v\n-  got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestARPUnmarshalBinary(t *testing.T) {
+	var tests = []struct {
+		desc string
+		b    []byte
+		a    *ARP
+		err  error
+	}{
+		{
+			desc: "short header",
+			b:    arpRequest[:7],
+			err:  io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "short addresses",
+			b:    arpRequest[:len(arpRequest)-1],
+			err:  io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "zero hardware length",
+			b:    []byte{0x00, 0x01, 0x08, 0x00, 0x00, 0x04, 0x00, 0x01},
+			err:  ErrInvalidARP,
+		},
+		{
+			desc: "bad Ethernet hardware length",
+			b:    []byte{0x00, 0x01, 0x08, 0x00, 0x08, 0x04, 0x00, 0x01},
+			err:  ErrInvalidARP,
+		},
+		{
+			desc: "bad IPv4 protocol length",
+			b:    []byte{0x00, 0x01, 0x08, 0x00, 0x06, 0x10, 0x00, 0x01},
+			err:  ErrInvalidARP,
+		},
+		{
+			desc: "OK",
+			b:    arpRequest,
+			a: &ARP{
+				HardwareType:       ARPHardwareTypeEthernet,
+				ProtocolType:       uint16(EtherTypeIPv4),
+				HardwareLen:        6,
+				ProtocolLen:        4,
+				Operation:          ARPOperationRequest,
+				SenderHardwareAddr: net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+				SenderProtocolAddr: net.IP{192, 168, 1, 1},
+				TargetHardwareAddr: net.HardwareAddr{0, 0, 0, 0, 0, 0},
+				TargetProtocolAddr: net.IP{192, 168, 1, 2},
+			},
+		},
+		{
+			desc: "OK, padding",
+			b:    append(append([]byte(nil), arpRequest...), make([]byte, 18)...),
+			a: &ARP{
+				HardwareType:       ARPHardwareTypeEthernet,
+				ProtocolType:       uint16(EtherTypeIPv4),
+				HardwareLen:        6,
+				ProtocolLen:        4,
+				Operation:          ARPOperationRequest,
+				SenderHardwareAddr: net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+				SenderProtocolAddr: net.IP{192, 168, 1, 1},
+				TargetHardwareAddr: net.HardwareAddr{0, 0, 0, 0, 0, 0},
+				TargetProtocolAddr: net.IP{192, 168, 1, 2},
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			a := new(ARP)
+			err := a.UnmarshalBinary(tt.b)
+			if want, got := tt.err, err; !errors.Is(got, want) {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+			if err != nil {
+				return
+			}
+
+			if want, got := tt.a, a; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected ARP:\n- want: %#v\n-  got: %#v",
+					i, tt.desc, want, got)
+			}
+
+			// Addresses must not alias the input.
+			b := append([]byte(nil), tt.b...)
+			if err := a.UnmarshalBinary(b); err != nil {
+				t.Fatalf("[%02d] test %q, failed to unmarshal ARP: %v",
+					i, tt.desc, err)
+			}
+			b[8] = 0xff
+			if want, got := tt.a, a; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, ARP aliases input:\n- want: %#v\n-  got: %#v",
+					i, tt.desc, want, got)
+			}
+
+			// The ARP must round-trip, without any padding.
+			ab, err := a.MarshalBinary()
+			if err != nil {
+				t.Fatalf("[%02d] test %q, failed to marshal ARP: %v",
+					i, tt.desc, err)
+			}
+			if want, got := arpRequest, ab; !bytes.Equal(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected ARP bytes:\n- want: %v\n-  got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameARP(t *testing.T) {
+	f := &Frame{
+		Destination: Broadcast,
+		Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+		EtherType:   EtherTypeARP,
+		Payload:     arpRequest,
+	}
+
+	// Decode the ARP from a padded Frame, as it would be received.
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+
+	rf := new(Frame)
+	if err := rf.UnmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal Frame: %v", err)
+	}
+
+	a, err := rf.ARP()
+	if err != nil {
+		t.Fatalf("failed to decode ARP: %v", err)
+	}
+
+	if want, got := uint16(ARPOperationRequest), a.Operation; want != got {
+		t.Fatalf("unexpected operation: %v != %v", want, got)
+	}
+	if want, got := net.IPv4(192, 168, 1, 2), a.TargetProtocolAddr; !want.Equal(got) {
+		t.Fatalf("unexpected target protocol address: %v != %v", want, got)
+	}
+
+	f.EtherType = EtherTypeIPv4
+	if _, err := f.ARP(); !errors.Is(err, ErrInvalidARP) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestNewGratuitousARP(t *testing.T) {
+	mac := net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad}
+
+	var tests = []struct {
+		desc string
+		mac  net.HardwareAddr
+		ip   net.IP
+		msg  string
+	}{
+		{
+			desc: "short hardware address",
+			mac:  net.HardwareAddr{0xde, 0xad},
+			ip:   net.IPv4(192, 168, 1, 1),
+			msg:  "invalid ARP packet: hardware address de:ad is not 6 bytes",
+		},
+		{
+			desc: "IPv6 address",
+			mac:  mac,
+			ip:   net.ParseIP("2001:db8::1"),
+			msg:  "invalid ARP packet: 2001:db8::1 is not an IPv4 address",
+		},
+		{
+			desc: "nil address",
+			mac:  mac,
+			msg:  "invalid ARP packet: <nil> is not an IPv4 address",
+		},
+		{
+			desc: "OK",
+			mac:  mac,
+			ip:   net.IPv4(192, 168, 1, 1),
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f, err := NewGratuitousARP(tt.mac, tt.ip)
+			if err != nil {
+				if !errors.Is(err, ErrInvalidARP) {
+					t.Fatalf("[%02d] test %q, unexpected error: %v",
+						i, tt.desc, err)
+				}
+
+				if want, got := tt.msg, err.Error(); want != got {
+					t.Fatalf("[%02d] test %q, unexpected error message: %q != %q",
+						i, tt.desc, want, got)
+				}
+				return
+			}
+			if tt.msg != "" {
+				t.Fatalf("[%02d] test %q, expected error %q", i, tt.desc, tt.msg)
+			}
+
+			if want, got := Broadcast, f.Destination; !bytes.Equal(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected destination: %v != %v",
+					i, tt.desc, want, got)
+			}
+			if want, got := tt.mac, f.Source; !bytes.Equal(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected source: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			// The Frame must be ready to send, and decode as a gratuitous
+			// ARP once received.
+			b, err := f.MarshalBinary()
+			if err != nil {
+				t.Fatalf("[%02d] test %q, failed to marshal Frame: %v",
+					i, tt.desc, err)
+			}
+
+			rf := new(Frame)
+			if err := rf.UnmarshalBinary(b); err != nil {
+				t.Fatalf("[%02d] test %q, failed to unmarshal Frame: %v",
+					i, tt.desc, err)
+			}
+
+			a, err := rf.ARP()
+			if err != nil {
+				t.Fatalf("[%02d] test %q, failed to decode ARP: %v",
+					i, tt.desc, err)
+			}
+
+			want := &ARP{
+				HardwareType:       ARPHardwareTypeEthernet,
+				ProtocolType:       uint16(EtherTypeIPv4),
+				HardwareLen:        6,
+				ProtocolLen:        4,
+				Operation:          ARPOperationRequest,
+				SenderHardwareAddr: tt.mac,
+				SenderProtocolAddr: net.IP{192, 168, 1, 1},
+				TargetHardwareAddr: net.HardwareAddr{0, 0, 0, 0, 0, 0},
+				TargetProtocolAddr: net.IP{192, 168, 1, 1},
+			}
+
+			if got := a; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected ARP:\n- want: %#v\n-  got: %#v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestNewGratuitousARPDoesNotAliasAddress(t *testing.T) {
+	want := net.HardwareAddr{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+
+	f, err := NewGratuitousARP(net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad}, net.IPv4(192, 168, 1, 1))
+	if err != nil {
+		t.Fatalf("failed to create gratuitous ARP Frame: %v", err)
+	}
+
+	// Modifying a returned Frame must not modify the package variable.
+	f.Destination[5] = 0x00
+	if got := Broadcast; !bytes.Equal(want, got) {
+		t.Fatalf("Broadcast modified: %v != %v", want, got)
+	}
+}
@@ -0,0 +1,111 @@
+package ethernet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"runtime"
+	"sync"
+)
+
+// MarshalBatch marshals each Frame in frames sequentially into dst, without
+// allocating for the Frames themselves, and returns the offset in dst at
+// which each Frame begins. The end of the final Frame is the sum of its
+// offset and Length.
+//
+// If dst runs out of space, an error wrapping ErrBufferTooSmall is returned
+// along with the offsets of the Frames which were marshaled successfully.
+// MarshalBatch otherwise returns the same errors as MarshalBinary.
+func MarshalBatch(dst []byte, frames []*Frame) ([]int, error) {
+	return marshalBatch(dst, frames, false)
+}
+
+// MarshalBatchFCS is like MarshalBatch, but follows each Frame with a 4-byte
+// IEEE CRC32 frame check sequence, as MarshalFCS does.
+func MarshalBatchFCS(dst []byte, frames []*Frame) ([]int, error) {
+	return marshalBatch(dst, frames, true)
+}
+
+// marshalBatch implements MarshalBatch and MarshalBatchFCS.
+func marshalBatch(dst []byte, frames []*Frame, fcs bool) ([]int, error) {
+	offsets := make([]int, 0, len(frames))
+
+	var off int
+	for i, f := range frames {
+		l := f.Length()
+		if fcs {
+			l += 4
+		}
+
+		if len(dst[off:]) < l {
+			return offsets, fmt.Errorf("%w: frame %d needs %d bytes, have %d",
+				ErrBufferTooSmall, i, l, len(dst[off:]))
+		}
+
+		b := dst[off : off+l]
+		if fcs {
+			if _, err := f.read(b[:l-4]); err != nil {
+				return offsets, err
+			}
+
+			binary.BigEndian.PutUint32(b[l-4:], crc32.ChecksumIEEE(f.fcsBody(b[:l-4])))
+		} else {
+			if _, err := f.read(b); err != nil {
+				return offsets, err
+			}
+		}
+
+		offsets = append(offsets, off)
+		off += l
+	}
+
+	return offsets, nil
+}
+
+// VerifyFCSBatch verifies the 4-byte IEEE CRC32 frame check sequence at the
+// end of each byte slice in frames, as UnmarshalFCS does, without
+// unmarshaling any Frames. This is useful for quickly validating a large
+// capture.
+//
+// The returned slice has the same length and order as frames: each element
+// is nil if the corresponding frame check sequence is valid, ErrInvalidFCS
+// if it is not, or io.ErrUnexpectedEOF if the byte slice is shorter than 4
+// bytes.
+//
+// The work is divided between up to GOMAXPROCS goroutines, each of which
+// verifies a contiguous range of frames. VerifyFCSBatch returns once every
+// frame has been verified. The byte slices must not be modified until then.
+func VerifyFCSBatch(frames [][]byte) []error {
+	errs := make([]error, len(frames))
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(frames) {
+		workers = len(frames)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		// Divide the frames as evenly as possible.
+		start := i * len(frames) / workers
+		end := (i + 1) * len(frames) / workers
+
+		go func() {
+			defer wg.Done()
+
+			for j := start; j < end; j++ {
+				if len(frames[j]) < 4 {
+					errs[j] = io.ErrUnexpectedEOF
+					continue
+				}
+
+				errs[j] = verifyFCS(frames[j])
+			}
+		}()
+	}
+
+	wg.Wait()
+	return errs
+}
@@ -0,0 +1,141 @@
+package ethernet
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestMarshalBatch(t *testing.T) {
+	frames := []*Frame{
+		{
+			Destination: Broadcast,
+			Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+			EtherType:   EtherTypeARP,
+			Payload:     []byte{0x01, 0x02},
+		},
+		{
+			Destination: net.HardwareAddr{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33},
+			Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+			VLAN:        []*VLAN{{ID: 10}},
+			EtherType:   EtherTypeIPv4,
+			Payload:     vectorPayload(100),
+		},
+	}
+
+	var tests = []struct {
+		desc    string
+		fcs     bool
+		size    int
+		offsets []int
+		err     error
+	}{
+		{
+			desc:    "OK",
+			size:    1500,
+			offsets: []int{0, 60},
+		},
+		{
+			desc:    "OK, FCS",
+			fcs:     true,
+			size:    1500,
+			offsets: []int{0, 64},
+		},
+		{
+			desc:    "exact size",
+			size:    60 + 118,
+			offsets: []int{0, 60},
+		},
+		{
+			desc:    "too small",
+			size:    60 + 117,
+			offsets: []int{0},
+			err:     ErrBufferTooSmall,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			marshalBatch, marshal := MarshalBatch, (*Frame).MarshalBinary
+			if tt.fcs {
+				marshalBatch, marshal = MarshalBatchFCS, (*Frame).MarshalFCS
+			}
+
+			dst := make([]byte, tt.size)
+			offsets, err := marshalBatch(dst, frames)
+			if want, got := tt.err, err; !errors.Is(got, want) {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.offsets, offsets; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected offsets: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			for j, off := range offsets {
+				want, err := marshal(frames[j])
+				if err != nil {
+					t.Fatalf("[%02d] test %q, failed to marshal Frame: %v",
+						i, tt.desc, err)
+				}
+
+				if got := dst[off : off+len(want)]; !bytes.Equal(want, got) {
+					t.Fatalf("[%02d] test %q, unexpected Frame %d bytes:\n- want: %v\n- got: %v",
+						i, tt.desc, j, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestMarshalBatchInvalidVLAN(t *testing.T) {
+	frames := []*Frame{{
+		VLAN: []*VLAN{{ID: VLANMax}},
+	}}
+
+	if _, err := MarshalBatch(make([]byte, 1500), frames); err != ErrInvalidVLAN {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestVerifyFCSBatch(t *testing.T) {
+	if errs := VerifyFCSBatch(nil); len(errs) != 0 {
+		t.Fatalf("unexpected errors for empty batch: %v", errs)
+	}
+
+	// Enough frames to be divided between several goroutines, with an
+	// invalid frame check sequence or a short frame at known indices.
+	var (
+		frames [][]byte
+		want   []error
+	)
+	for i := 0; i < 100; i++ {
+		v := TestVectors[i%len(TestVectors)]
+
+		b, err := v.Frame.MarshalFCS()
+		if err != nil {
+			t.Fatalf("failed to marshal Frame: %v", err)
+		}
+
+		var werr error
+		switch {
+		case i%7 == 3:
+			b[len(b)-1]++
+			werr = ErrInvalidFCS
+		case i%11 == 5:
+			b = b[:3]
+			werr = io.ErrUnexpectedEOF
+		}
+
+		frames = append(frames, b)
+		want = append(want, werr)
+	}
+
+	if got := VerifyFCSBatch(frames); !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected errors:\n- want: %v\n- got: %v", want, got)
+	}
+}
@@ -0,0 +1,139 @@
+package ethernet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+)
+
+// IEEE 802.3 Slow Protocols subtypes, which identify the protocol carried
+// by a Frame with EtherType EtherTypeSlowProtocols.
+const (
+	SlowProtocolLACP   = 0x01
+	SlowProtocolMarker = 0x02
+	SlowProtocolOAM    = 0x03
+)
+
+// MAC Control opcodes, which identify the operation carried by a Frame with
+// EtherType EtherTypeMACControl.
+const (
+	// opcodePause is the IEEE 802.3x PAUSE opcode.
+	opcodePause = 0x0001
+
+	// opcodePFC is the IEEE 802.1Qbb Priority Flow Control opcode.
+	opcodePFC = 0x0101
+)
+
+// SlowProtocolSubtype returns the IEEE 802.3 Slow Protocols subtype of a
+// Frame, such as SlowProtocolLACP, and true, if the Frame's EtherType is
+// EtherTypeSlowProtocols and its payload is not empty. Otherwise, it returns
+// false.
+func (f *Frame) SlowProtocolSubtype() (byte, bool) {
+	if f.EtherType != EtherTypeSlowProtocols || len(f.Payload) == 0 {
+		return 0, false
+	}
+
+	return f.Payload[0], true
+}
+
+// NewPauseFrame creates an IEEE 802.3x PAUSE Frame from the hardware address
+// src, which requests that the receiver pause transmission for quanta units
+// of 512 bit times. A quanta of 0 requests that transmission resume
+// immediately.
+func NewPauseFrame(src net.HardwareAddr, quanta uint16) *Frame {
+	// Opcode and quanta, followed by reserved bytes which pad the payload
+	// to the minimum size.
+	p := make([]byte, MinPayload)
+	binary.BigEndian.PutUint16(p[0:2], opcodePause)
+	binary.BigEndian.PutUint16(p[2:4], quanta)
+
+	return &Frame{
+		Destination: cloneBytes(MulticastMACControl),
+		Source:      src,
+		EtherType:   EtherTypeMACControl,
+		Payload:     p,
+	}
+}
+
+// ParsePauseFrame returns the pause quanta of an IEEE 802.3x PAUSE Frame,
+// and true. If f is not a PAUSE frame addressed to the reserved MAC Control
+// multicast address, it returns false.
+func ParsePauseFrame(f *Frame) (quanta uint16, ok bool) {
+	p, ok := macControlPayload(f, opcodePause, 4)
+	if !ok {
+		return 0, false
+	}
+
+	return binary.BigEndian.Uint16(p[2:4]), true
+}
+
+// NewPFCFrame creates an IEEE 802.1Qbb Priority Flow Control Frame from the
+// hardware address src. For each of the 8 priority classes where enable is
+// true, the receiver is requested to pause transmission of that class for
+// the corresponding quanta units of 512 bit times.
+//
+// The quanta of a class which is not enabled is ignored by receivers, and
+// is always transmitted as 0.
+func NewPFCFrame(src net.HardwareAddr, enable [8]bool, quanta [8]uint16) *Frame {
+	// Opcode, class-enable vector, and 8 quanta, followed by reserved bytes
+	// which pad the payload to the minimum size.
+	p := make([]byte, MinPayload)
+	binary.BigEndian.PutUint16(p[0:2], opcodePFC)
+
+	// The most significant octet of the class-enable vector is reserved,
+	// and bit n of the least significant octet enables class n.
+	for i := range enable {
+		if !enable[i] {
+			continue
+		}
+
+		p[3] |= 1 << uint(i)
+		binary.BigEndian.PutUint16(p[4+(2*i):6+(2*i)], quanta[i])
+	}
+
+	return &Frame{
+		Destination: cloneBytes(MulticastMACControl),
+		Source:      src,
+		EtherType:   EtherTypeMACControl,
+		Payload:     p,
+	}
+}
+
+// ParsePFCFrame returns the class-enable vector and quanta of an IEEE
+// 802.1Qbb Priority Flow Control Frame, and true. The quanta of a class
+// which is not enabled is always 0.
+//
+// If f is not a Priority Flow Control frame addressed to the reserved MAC
+// Control multicast address, or the reserved bits of its class-enable
+// vector are set, it returns false.
+func ParsePFCFrame(f *Frame) (enable [8]bool, quanta [8]uint16, ok bool) {
+	p, ok := macControlPayload(f, opcodePFC, 20)
+	if !ok || p[2] != 0 {
+		return enable, quanta, false
+	}
+
+	for i := range enable {
+		if p[3]&(1<<uint(i)) == 0 {
+			continue
+		}
+
+		enable[i] = true
+		quanta[i] = binary.BigEndian.Uint16(p[4+(2*i) : 6+(2*i)])
+	}
+
+	return enable, quanta, true
+}
+
+// macControlPayload returns the payload of a MAC Control Frame, and true,
+// if the Frame has the specified opcode and a payload of at least n bytes.
+func macControlPayload(f *Frame, opcode uint16, n int) ([]byte, bool) {
+	if f.EtherType != EtherTypeMACControl || !bytes.Equal(f.Destination, MulticastMACControl) {
+		return nil, false
+	}
+
+	if len(f.Payload) < n || binary.BigEndian.Uint16(f.Payload[0:2]) != opcode {
+		return nil, false
+	}
+
+	return f.Payload, true
+}
@@ -0,0 +1,226 @@
+package ethernet
+
+import (
+	"bytes"
+	"net"
+	"testing"
+)
+
+func TestFrameSlowProtocolSubtype(t *testing.T) {
+	var tests = []struct {
+		desc    string
+		f       *Frame
+		subtype byte
+		ok      bool
+	}{
+		{
+			desc: "IPv4",
+			f: &Frame{
+				EtherType: EtherTypeIPv4,
+				Payload:   []byte{SlowProtocolLACP},
+			},
+		},
+		{
+			desc: "slow protocols, empty payload",
+			f: &Frame{
+				EtherType: EtherTypeSlowProtocols,
+			},
+		},
+		{
+			desc: "LACP",
+			f: &Frame{
+				EtherType: EtherTypeSlowProtocols,
+				Payload:   []byte{SlowProtocolLACP, 0x01},
+			},
+			subtype: SlowProtocolLACP,
+			ok:      true,
+		},
+		{
+			desc: "OAM",
+			f: &Frame{
+				EtherType: EtherTypeSlowProtocols,
+				Payload:   []byte{SlowProtocolOAM},
+			},
+			subtype: SlowProtocolOAM,
+			ok:      true,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			subtype, ok := tt.f.SlowProtocolSubtype()
+			if want, got := tt.ok, ok; want != got {
+				t.Fatalf("[%02d] test %q, unexpected ok: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.subtype, subtype; want != got {
+				t.Fatalf("[%02d] test %q, unexpected subtype: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestPauseFrame(t *testing.T) {
+	src := net.HardwareAddr{0, 1, 0, 1, 0, 1}
+
+	f := NewPauseFrame(src, 0xabcd)
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal PAUSE frame: %v", err)
+	}
+
+	want := append([]byte{
+		0x01, 0x80, 0xc2, 0x00, 0x00, 0x01,
+		0, 1, 0, 1, 0, 1,
+		0x88, 0x08,
+		0x00, 0x01,
+		0xab, 0xcd,
+	}, make([]byte, 42)...)
+
+	if got := b; !bytes.Equal(want, got) {
+		t.Fatalf("unexpected PAUSE frame bytes:\n- want: %v\n- got: %v", want, got)
+	}
+
+	f2 := new(Frame)
+	if err := f2.UnmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal PAUSE frame: %v", err)
+	}
+
+	quanta, ok := ParsePauseFrame(f2)
+	if !ok {
+		t.Fatal("failed to parse PAUSE frame")
+	}
+	if want, got := uint16(0xabcd), quanta; want != got {
+		t.Fatalf("unexpected quanta: %v != %v", want, got)
+	}
+}
+
+func TestParsePauseFrame(t *testing.T) {
+	var tests = []struct {
+		desc string
+		f    *Frame
+	}{
+		{
+			desc: "wrong EtherType",
+			f: &Frame{
+				Destination: net.HardwareAddr{0x01, 0x80, 0xc2, 0x00, 0x00, 0x01},
+				EtherType:   EtherTypeIPv4,
+				Payload:     []byte{0x00, 0x01, 0x00, 0x01},
+			},
+		},
+		{
+			desc: "wrong destination",
+			f: &Frame{
+				Destination: Broadcast,
+				EtherType:   EtherTypeMACControl,
+				Payload:     []byte{0x00, 0x01, 0x00, 0x01},
+			},
+		},
+		{
+			desc: "wrong opcode",
+			f: &Frame{
+				Destination: net.HardwareAddr{0x01, 0x80, 0xc2, 0x00, 0x00, 0x01},
+				EtherType:   EtherTypeMACControl,
+				Payload:     []byte{0x01, 0x01, 0x00, 0x01},
+			},
+		},
+		{
+			desc: "short payload",
+			f: &Frame{
+				Destination: net.HardwareAddr{0x01, 0x80, 0xc2, 0x00, 0x00, 0x01},
+				EtherType:   EtherTypeMACControl,
+				Payload:     []byte{0x00, 0x01, 0x00},
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if _, ok := ParsePauseFrame(tt.f); ok {
+				t.Fatalf("[%02d] test %q, expected parse failure", i, tt.desc)
+			}
+		})
+	}
+}
+
+func TestPFCFrame(t *testing.T) {
+	src := net.HardwareAddr{0, 1, 0, 1, 0, 1}
+	enable := [8]bool{true, false, false, true, false, false, false, true}
+	quanta := [8]uint16{0x0001, 0xffff, 0, 0x0300, 0, 0, 0, 0xabcd}
+
+	f := NewPFCFrame(src, enable, quanta)
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal PFC frame: %v", err)
+	}
+
+	want := append([]byte{
+		0x01, 0x80, 0xc2, 0x00, 0x00, 0x01,
+		0, 1, 0, 1, 0, 1,
+		0x88, 0x08,
+		0x01, 0x01,
+		0x00, 0x89,
+		0x00, 0x01,
+		0x00, 0x00,
+		0x00, 0x00,
+		0x03, 0x00,
+		0x00, 0x00,
+		0x00, 0x00,
+		0x00, 0x00,
+		0xab, 0xcd,
+	}, make([]byte, 26)...)
+
+	if got := b; !bytes.Equal(want, got) {
+		t.Fatalf("unexpected PFC frame bytes:\n- want: %v\n- got: %v", want, got)
+	}
+
+	f2 := new(Frame)
+	if err := f2.UnmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal PFC frame: %v", err)
+	}
+
+	gotEnable, gotQuanta, ok := ParsePFCFrame(f2)
+	if !ok {
+		t.Fatal("failed to parse PFC frame")
+	}
+	if want, got := enable, gotEnable; want != got {
+		t.Fatalf("unexpected class-enable vector: %v != %v", want, got)
+	}
+
+	// The quanta of disabled classes is not transmitted.
+	quanta[1] = 0
+	if want, got := quanta, gotQuanta; want != got {
+		t.Fatalf("unexpected quanta: %v != %v", want, got)
+	}
+
+	// PFC frames are not PAUSE frames.
+	if _, ok := ParsePauseFrame(f2); ok {
+		t.Fatal("PFC frame parsed as PAUSE frame")
+	}
+
+	// Reserved bits in the class-enable vector are invalid.
+	f2.Payload[2] = 0x01
+	if _, _, ok := ParsePFCFrame(f2); ok {
+		t.Fatal("PFC frame with reserved class-enable bits parsed")
+	}
+}
+
+func TestMACControlFramesDoNotAliasAddress(t *testing.T) {
+	want := net.HardwareAddr{0x01, 0x80, 0xc2, 0x00, 0x00, 0x01}
+	src := net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad}
+
+	frames := []*Frame{
+		NewPauseFrame(src, 1),
+		NewPFCFrame(src, [8]bool{true}, [8]uint16{1}),
+	}
+
+	for i, f := range frames {
+		// Modifying a returned Frame must not modify the package variable.
+		f.Destination[5] = 0xff
+		if got := MulticastMACControl; !bytes.Equal(want, got) {
+			t.Fatalf("[%02d] MulticastMACControl modified: %v != %v", i, want, got)
+		}
+	}
+}
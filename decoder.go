@@ -0,0 +1,342 @@
+package ethernet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+const (
+	// preamble is the value of each byte of an Ethernet preamble.
+	preamble = 0x55
+
+	// preambleLen is the length of a complete Ethernet preamble.
+	preambleLen = 7
+
+	// sfd is the Ethernet start frame delimiter, which follows the preamble.
+	sfd = 0xd5
+)
+
+var (
+	// ErrReservedMulticast is returned by Decoder.Decode when a Frame is
+	// addressed to a reserved multicast address, and the Decoder's
+	// DropReservedMulticast option is set.
+	ErrReservedMulticast = errors.New("frame addressed to reserved multicast address")
+
+	// ErrTooManyVLANs is returned by Decoder.Decode when a Frame carries more
+	// VLAN tags than permitted by the Decoder's MaxVLANTags option.
+	ErrTooManyVLANs = errors.New("too many VLAN tags")
+
+	// ErrMemoryLimit is returned by Decoder.DecodeAll when decoding another
+	// Frame would exceed the Decoder's MaxTotalBytes option.
+	ErrMemoryLimit = errors.New("decoder memory limit exceeded")
+)
+
+// A Decoder unmarshals Frames from byte slices, and keeps statistics about
+// the Frames it has decoded.
+//
+// The zero value of a Decoder is ready to use. A Decoder is not safe for
+// concurrent use by multiple goroutines.
+type Decoder struct {
+	// StripPreamble specifies that a leading Ethernet preamble and start
+	// frame delimiter should be removed from each byte slice, if present,
+	// before it is decoded. Only a complete 7 byte preamble is removed; see
+	// the StripPreamble function for details.
+	StripPreamble bool
+
+	// LittleEndian specifies that the VLAN tag protocol identifiers, VLAN
+	// tag control information, and EtherType of each byte slice should be
+	// read in little-endian byte order, rather than network byte order.
+	//
+	// LittleEndian is non-standard and hazardous: no conforming device
+	// transmits Frames in this form, and setting it for ordinary traffic
+	// produces nonsensical Frames without any error. It exists only for
+	// research use, such as reverse-engineering malformed or experimental
+	// captures. The decoded Frame is indistinguishable from one decoded in
+	// network byte order, and marshals in network byte order.
+	LittleEndian bool
+
+	// DropReservedMulticast specifies that Frames addressed to a reserved
+	// multicast address, as reported by Frame.IsReservedMulticast, should be
+	// dropped, as a bridge must not forward them. Decode unmarshals such a
+	// Frame, but returns ErrReservedMulticast and counts it as dropped.
+	DropReservedMulticast bool
+
+	// ReuseVLANs specifies that the VLAN structs referenced by a Frame's
+	// VLAN field, including any in the slice's spare capacity, should be
+	// overwritten with the VLAN tags of each decoded Frame, rather than
+	// allocating new VLAN structs, which reduces garbage collection
+	// pressure when the same Frame is passed to Decode repeatedly.
+	//
+	// When ReuseVLANs is set, the caller must not retain pointers to a
+	// Frame's VLAN structs across calls to Decode, because they will be
+	// modified. A decoded Frame without VLAN tags may have an empty, non-nil
+	// VLAN slice. The VLAN tags of each byte slice are verified before any
+	// VLAN struct is overwritten, so if Decode returns an error because a
+	// header is truncated or invalid, the Frame and its VLAN structs still
+	// hold the previously decoded Frame.
+	ReuseVLANs bool
+
+	// MaxVLANTags, if greater than 0, specifies the maximum number of VLAN
+	// tags which are parsed from each Frame, to bound the work done for
+	// malicious Frames containing many stacked tags. By default, the number
+	// of tags is unlimited.
+	//
+	// If a Frame carries more than MaxVLANTags tags, Decode returns
+	// ErrTooManyVLANs, unless TruncateVLANTags is set.
+	MaxVLANTags int
+
+	// TruncateVLANTags specifies that a Frame carrying more than MaxVLANTags
+	// VLAN tags should be decoded rather than rejected. Parsing stops after
+	// MaxVLANTags tags: the tag protocol identifier of the next tag is
+	// treated as the Frame's EtherType, the remaining tags are left at the
+	// beginning of its Payload, and its TagsTruncated field is set.
+	TruncateVLANTags bool
+
+	// TrailerLength, if greater than 0, specifies the length of a
+	// vendor-specific trailer which follows the payload of each Frame, such
+	// as a port tag appended by a switch. The final TrailerLength bytes of
+	// each byte slice, after any preamble is stripped, are copied into the
+	// Frame's Trailer field, rather than its Payload. If a frame check
+	// sequence is present, it must be removed before Decode is called, or
+	// FCS must be set.
+	//
+	// If a byte slice is too short to contain both a Frame header and the
+	// trailer, Decode returns io.ErrUnexpectedEOF.
+	TrailerLength int
+
+	// TrailerExcludedFromFCS specifies that the trailer of each Frame, as
+	// specified by TrailerLength, is not covered by its frame check sequence
+	// when FCS is set. The TrailerExcludedFromFCS field of each Frame with a
+	// trailer is set to match, so that the Frame marshals to the same form.
+	TrailerExcludedFromFCS bool
+
+	// FCS specifies that each byte slice, after any preamble is stripped,
+	// ends with a 4-byte IEEE CRC32 frame check sequence, which is verified
+	// and removed before the Frame is decoded, as UnmarshalFCS does. Any
+	// trailer precedes the frame check sequence.
+	//
+	// If a byte slice is shorter than 4 bytes, Decode returns
+	// io.ErrUnexpectedEOF. If the frame check sequence is invalid, Decode
+	// returns ErrInvalidFCS.
+	FCS bool
+
+	// KeepRaw specifies that a copy of each byte slice should be stored in
+	// the Raw field of the decoded Frame, as UnmarshalBinaryKeepRaw does.
+	// Raw contains the byte slice exactly as it was passed to Decode, before
+	// any preamble is stripped or trailer is removed.
+	KeepRaw bool
+
+	// MaxTotalBytes, if greater than 0, specifies the maximum number of bytes
+	// which DecodeAll may allocate for the Frames it decodes in a single
+	// call, to bound its memory use for huge or untrusted captures. By
+	// default, the number of bytes is unlimited.
+	//
+	// Each byte slice is charged its full length before it is decoded, which
+	// bounds the bytes allocated for its Frame's Payload and Trailer, and is
+	// charged its length again if KeepRaw is set. MaxTotalBytes does not
+	// apply to Decode.
+	MaxTotalBytes int
+
+	stats DecoderStats
+}
+
+// DecoderStats contains statistics about the Frames decoded by a Decoder.
+type DecoderStats struct {
+	// Frames is the number of Frames decoded successfully.
+	Frames uint64
+
+	// Errors is the number of byte slices which could not be decoded.
+	Errors uint64
+
+	// Dropped is the number of Frames which were decoded, but dropped due to
+	// one of the Decoder's options, such as DropReservedMulticast. Dropped
+	// Frames are not counted in Frames or VLANDepth.
+	Dropped uint64
+
+	// VLANDepth is a histogram of the number of VLAN tags carried by each
+	// Frame decoded successfully. Indices 0, 1, and 2 count Frames with
+	// exactly that many tags, and index 3 counts Frames with 3 or more tags.
+	VLANDepth [4]uint64
+}
+
+// Decode unmarshals a byte slice into a Frame, and updates the Decoder's
+// statistics. Decode returns the same errors as Frame.UnmarshalBinary, or
+// ErrReservedMulticast if the Frame is dropped by DropReservedMulticast.
+func (d *Decoder) Decode(f *Frame, b []byte) error {
+	raw := b
+	if d.StripPreamble {
+		b, _ = StripPreamble(b)
+	}
+
+	var fcs []byte
+	if d.FCS {
+		if len(b) < 4 {
+			d.stats.Errors++
+			return io.ErrUnexpectedEOF
+		}
+
+		b, fcs = b[:len(b)-4], b[len(b)-4:]
+	}
+	body := b
+
+	var trailer []byte
+	if d.TrailerLength > 0 {
+		if len(b) < 14+d.TrailerLength {
+			d.stats.Errors++
+			return io.ErrUnexpectedEOF
+		}
+
+		b, trailer = b[:len(b)-d.TrailerLength], b[len(b)-d.TrailerLength:]
+	}
+
+	if fcs != nil {
+		// Verify the frame check sequence over the Frame and its trailer,
+		// unless the trailer is excluded.
+		if d.TrailerExcludedFromFCS {
+			body = b
+		}
+
+		if binary.BigEndian.Uint32(fcs) != crc32.ChecksumIEEE(body) {
+			d.stats.Errors++
+			return ErrInvalidFCS
+		}
+	}
+	if d.LittleEndian {
+		b = swapTypeFields(b)
+	}
+
+	opts := unmarshalOptions{
+		headerOptions: headerOptions{
+			reuse:   d.ReuseVLANs,
+			maxTags: d.MaxVLANTags,
+		},
+		truncateTags: d.TruncateVLANTags,
+	}
+
+	if err := f.unmarshalBinary(b, opts); err != nil {
+		d.stats.Errors++
+		return err
+	}
+	if trailer != nil {
+		f.Trailer = append([]byte(nil), trailer...)
+		f.TrailerExcludedFromFCS = d.TrailerExcludedFromFCS
+	}
+	if d.KeepRaw {
+		f.Raw = append([]byte(nil), raw...)
+	}
+
+	if d.DropReservedMulticast && f.IsReservedMulticast() {
+		d.stats.Dropped++
+		return ErrReservedMulticast
+	}
+
+	d.stats.Frames++
+
+	depth := len(f.VLAN)
+	if depth > len(d.stats.VLANDepth)-1 {
+		depth = len(d.stats.VLANDepth) - 1
+	}
+	d.stats.VLANDepth[depth]++
+
+	return nil
+}
+
+// DecodeAll unmarshals each byte slice in bs into a new Frame with Decode,
+// and returns the Frames in order. Frames dropped by DropReservedMulticast
+// are skipped.
+//
+// If decoding a byte slice would exceed MaxTotalBytes, DecodeAll stops before
+// decoding it, and returns the Frames decoded so far with an error wrapping
+// ErrMemoryLimit. If a byte slice cannot be decoded, DecodeAll stops, and
+// returns the Frames decoded so far with an error wrapping the error from
+// Decode. In either case, the error reports how many Frames were decoded.
+func (d *Decoder) DecodeAll(bs [][]byte) ([]*Frame, error) {
+	var (
+		frames = make([]*Frame, 0, len(bs))
+		total  int
+	)
+
+	for i, b := range bs {
+		if d.MaxTotalBytes > 0 {
+			n := len(b)
+			if d.KeepRaw {
+				n *= 2
+			}
+
+			if total+n > d.MaxTotalBytes {
+				return frames, fmt.Errorf("%w: %d byte limit reached at input %d after decoding %d frames",
+					ErrMemoryLimit, d.MaxTotalBytes, i, len(frames))
+			}
+
+			total += n
+		}
+
+		f := new(Frame)
+		if err := d.Decode(f, b); err != nil {
+			if err == ErrReservedMulticast {
+				continue
+			}
+
+			return frames, fmt.Errorf("input %d after decoding %d frames: %w",
+				i, len(frames), err)
+		}
+
+		frames = append(frames, f)
+	}
+
+	return frames, nil
+}
+
+// StripPreamble removes a leading Ethernet preamble and start frame delimiter
+// (SFD) from b, as included by some capture hardware, and returns the
+// remaining bytes and true.
+//
+// A preamble is detected only as exactly 7 bytes with the value 0x55,
+// followed by the SFD byte 0xd5. A truncated preamble is not stripped, since
+// a Frame whose destination address begins with bytes such as 55:d5 could not
+// be told apart from one. If no preamble is detected, b is returned unmodified
+// with false.
+func StripPreamble(b []byte) ([]byte, bool) {
+	if len(b) < preambleLen+1 {
+		return b, false
+	}
+
+	for _, c := range b[:preambleLen] {
+		if c != preamble {
+			return b, false
+		}
+	}
+
+	if b[preambleLen] != sfd {
+		return b, false
+	}
+
+	return b[preambleLen+1:], true
+}
+
+// swapTypeFields returns a copy of the binary form of a Frame in b, with the
+// byte order of each VLAN tag protocol identifier, VLAN tag control
+// information, and the EtherType reversed. Fields which are truncated are
+// left unmodified, for Frame.UnmarshalBinary to report.
+func swapTypeFields(b []byte) []byte {
+	b = append([]byte(nil), b...)
+
+	for n := 12; n+2 <= len(b); n += 4 {
+		b[n], b[n+1] = b[n+1], b[n]
+		if !EtherType(binary.BigEndian.Uint16(b[n:n+2])).isVLANTPID() || len(b[n+2:]) < 2 {
+			break
+		}
+
+		b[n+2], b[n+3] = b[n+3], b[n+2]
+	}
+
+	return b
+}
+
+// Stats returns a snapshot of the Decoder's statistics.
+func (d *Decoder) Stats() DecoderStats {
+	return d.stats
+}
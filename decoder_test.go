@@ -0,0 +1,806 @@
+package ethernet
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestDecoderStats(t *testing.T) {
+	// tagged creates a Frame with n VLAN tags.
+	tagged := func(n int) []byte {
+		f := &Frame{
+			Payload: bytes.Repeat([]byte{0}, 50),
+		}
+		for i := 0; i < n; i++ {
+			f.VLAN = append(f.VLAN, &VLAN{ID: uint16(i + 1)})
+		}
+
+		b, err := f.MarshalBinary()
+		if err != nil {
+			t.Fatalf("failed to marshal Frame: %v", err)
+		}
+
+		return b
+	}
+
+	var d Decoder
+	for _, n := range []int{0, 0, 1, 2, 2, 3, 4, 5} {
+		if err := d.Decode(new(Frame), tagged(n)); err != nil {
+			t.Fatalf("failed to decode Frame with %d tags: %v", n, err)
+		}
+	}
+
+	if err := d.Decode(new(Frame), []byte{0}); err != io.ErrUnexpectedEOF {
+		t.Fatalf("unexpected error: %v != %v", io.ErrUnexpectedEOF, err)
+	}
+
+	want := DecoderStats{
+		Frames:    8,
+		Errors:    1,
+		VLANDepth: [4]uint64{2, 1, 2, 3},
+	}
+
+	if got := d.Stats(); want != got {
+		t.Fatalf("unexpected DecoderStats:\n- want: %+v\n- got: %+v", want, got)
+	}
+}
+
+func TestStripPreamble(t *testing.T) {
+	frame := []byte{
+		0, 1, 0, 1, 0, 1,
+		1, 0, 1, 0, 1, 0,
+		0x08, 0x00,
+	}
+
+	var tests = []struct {
+		desc string
+		b    []byte
+		out  []byte
+		ok   bool
+	}{
+		{
+			desc: "empty",
+		},
+		{
+			desc: "no preamble",
+			b:    frame,
+			out:  frame,
+		},
+		{
+			desc: "full preamble",
+			b:    append([]byte{0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0xd5}, frame...),
+			out:  frame,
+			ok:   true,
+		},
+		{
+			desc: "truncated preamble",
+			b:    append([]byte{0x55, 0x55, 0xd5}, frame...),
+			out:  append([]byte{0x55, 0x55, 0xd5}, frame...),
+		},
+		{
+			desc: "destination begins 55:d5",
+			b:    append([]byte{0x55, 0xd5, 0, 1, 0, 1}, frame[6:]...),
+			out:  append([]byte{0x55, 0xd5, 0, 1, 0, 1}, frame[6:]...),
+		},
+		{
+			desc: "full preamble, destination begins 55:d5",
+			b: append(
+				[]byte{0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0xd5, 0x55, 0xd5, 0, 1, 0, 1},
+				frame[6:]...,
+			),
+			out: append([]byte{0x55, 0xd5, 0, 1, 0, 1}, frame[6:]...),
+			ok:  true,
+		},
+		{
+			desc: "SFD only",
+			b:    append([]byte{0xd5}, frame...),
+			out:  append([]byte{0xd5}, frame...),
+		},
+		{
+			desc: "preamble without SFD",
+			b:    []byte{0x55, 0x55, 0x55},
+			out:  []byte{0x55, 0x55, 0x55},
+		},
+		{
+			desc: "preamble too long",
+			b:    append(bytes.Repeat([]byte{0x55}, 8), 0xd5),
+			out:  append(bytes.Repeat([]byte{0x55}, 8), 0xd5),
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			out, ok := StripPreamble(tt.b)
+			if want, got := tt.ok, ok; want != got {
+				t.Fatalf("[%02d] test %q, unexpected ok: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.out, out; !bytes.Equal(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected bytes:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestDecoderStripPreamble(t *testing.T) {
+	b := []byte{
+		0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0xd5,
+		0, 1, 0, 1, 0, 1,
+		1, 0, 1, 0, 1, 0,
+		0x08, 0x00,
+		0xde, 0xad,
+	}
+
+	d := Decoder{StripPreamble: true}
+	f := new(Frame)
+	if err := d.Decode(f, b); err != nil {
+		t.Fatalf("failed to decode Frame: %v", err)
+	}
+
+	if want, got := EtherTypeIPv4, f.EtherType; want != got {
+		t.Fatalf("unexpected EtherType: %v != %v", want, got)
+	}
+
+	if want, got := []byte{0xde, 0xad}, f.Payload; !bytes.Equal(want, got) {
+		t.Fatalf("unexpected payload: %v != %v", want, got)
+	}
+}
+
+func TestDecoderStripPreambleDestination(t *testing.T) {
+	// A Frame whose destination begins 55:d5 is not mistaken for one with a
+	// truncated preamble.
+	b := []byte{
+		0x55, 0xd5, 0, 1, 0, 1,
+		1, 0, 1, 0, 1, 0,
+		0x08, 0x00,
+		0xde, 0xad,
+	}
+
+	d := Decoder{StripPreamble: true}
+	f := new(Frame)
+	if err := d.Decode(f, b); err != nil {
+		t.Fatalf("failed to decode Frame: %v", err)
+	}
+
+	want := net.HardwareAddr{0x55, 0xd5, 0, 1, 0, 1}
+	if got := f.Destination; !bytes.Equal(want, got) {
+		t.Fatalf("unexpected destination: %v != %v", want, got)
+	}
+
+	if want, got := EtherTypeIPv4, f.EtherType; want != got {
+		t.Fatalf("unexpected EtherType: %v != %v", want, got)
+	}
+}
+
+func TestDecoderLittleEndian(t *testing.T) {
+	var tests = []struct {
+		desc string
+		b    []byte
+		f    *Frame
+		err  error
+	}{
+		{
+			desc: "empty",
+			b:    []byte{},
+			err:  io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "short addresses",
+			b:    []byte{0},
+			err:  io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "short EtherType",
+			b: []byte{
+				0, 1, 0, 1, 0, 1,
+				1, 0, 1, 0, 1, 0,
+				0x00,
+			},
+			err: io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "short VLAN",
+			b: []byte{
+				0, 1, 0, 1, 0, 1,
+				1, 0, 1, 0, 1, 0,
+				0x00, 0x81,
+				0x64,
+			},
+			err: io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "IPv4, VLAN",
+			b: []byte{
+				0, 1, 0, 1, 0, 1,
+				1, 0, 1, 0, 1, 0,
+				0x00, 0x81,
+				0x64, 0xa0,
+				0x00, 0x08,
+				0xde, 0xad,
+			},
+			f: &Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				VLAN: []*VLAN{{
+					Priority: PriorityVoice,
+					ID:       100,
+				}},
+				EtherType: EtherTypeIPv4,
+				Payload:   []byte{0xde, 0xad},
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			orig := append([]byte(nil), tt.b...)
+
+			d := Decoder{LittleEndian: true}
+			f := new(Frame)
+			err := d.Decode(f, tt.b)
+			if want, got := tt.err, err; want != got {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if !bytes.Equal(orig, tt.b) {
+				t.Fatalf("[%02d] test %q, input bytes were modified", i, tt.desc)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if want, got := tt.f, f; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected Frame:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestDecoderDropReservedMulticast(t *testing.T) {
+	lldp, err := (&Frame{
+		Destination: net.HardwareAddr{0x01, 0x80, 0xc2, 0x00, 0x00, 0x0e},
+		Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+		EtherType:   EtherTypeLLDP,
+	}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+
+	d := Decoder{DropReservedMulticast: true}
+	f := new(Frame)
+	if err := d.Decode(f, lldp); err != ErrReservedMulticast {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := d.Decode(f, TestVectors[0].Bytes); err != nil {
+		t.Fatalf("failed to decode Frame: %v", err)
+	}
+
+	want := DecoderStats{
+		Frames:    1,
+		Dropped:   1,
+		VLANDepth: [4]uint64{1, 0, 0, 0},
+	}
+	if got := d.Stats(); want != got {
+		t.Fatalf("unexpected stats:\n- want: %+v\n- got: %+v", want, got)
+	}
+
+	// Without the option, the Frame is decoded normally.
+	if err := new(Decoder).Decode(f, lldp); err != nil {
+		t.Fatalf("failed to decode Frame: %v", err)
+	}
+}
+
+func TestDecoderReuseVLANs(t *testing.T) {
+	d := Decoder{ReuseVLANs: true}
+	f := new(Frame)
+
+	// Q-in-Q, then 802.1Q, then untagged.
+	qinq, dot1q, untagged := TestVectors[3], TestVectors[2], TestVectors[0]
+
+	if err := d.Decode(f, qinq.Bytes); err != nil {
+		t.Fatalf("failed to decode Frame: %v", err)
+	}
+	if !f.Equal(qinq.Frame) {
+		t.Fatalf("unexpected Frame:\n- want: %v\n- got: %v", qinq.Frame, f)
+	}
+
+	outer, inner := f.VLAN[0], f.VLAN[1]
+
+	if err := d.Decode(f, dot1q.Bytes); err != nil {
+		t.Fatalf("failed to decode Frame: %v", err)
+	}
+	if !f.Equal(dot1q.Frame) {
+		t.Fatalf("unexpected Frame:\n- want: %v\n- got: %v", dot1q.Frame, f)
+	}
+	if f.VLAN[0] != outer {
+		t.Fatal("VLAN was not reused")
+	}
+
+	if err := d.Decode(f, untagged.Bytes); err != nil {
+		t.Fatalf("failed to decode Frame: %v", err)
+	}
+	if !f.Equal(untagged.Frame) {
+		t.Fatalf("unexpected Frame:\n- want: %v\n- got: %v", untagged.Frame, f)
+	}
+
+	// The spare capacity is reused when tags appear again.
+	if err := d.Decode(f, qinq.Bytes); err != nil {
+		t.Fatalf("failed to decode Frame: %v", err)
+	}
+	if !f.Equal(qinq.Frame) {
+		t.Fatalf("unexpected Frame:\n- want: %v\n- got: %v", qinq.Frame, f)
+	}
+	if f.VLAN[0] != outer || f.VLAN[1] != inner {
+		t.Fatal("VLANs were not reused")
+	}
+
+	// Only the hardware addresses and payload are allocated.
+	allocs := testing.AllocsPerRun(10, func() {
+		if err := d.Decode(f, qinq.Bytes); err != nil {
+			panic(err)
+		}
+	})
+	if want, got := 1.0, allocs; want != got {
+		t.Fatalf("unexpected number of allocations: %v != %v", want, got)
+	}
+}
+
+func TestDecoderMaxVLANTags(t *testing.T) {
+	b := []byte{
+		0, 1, 0, 1, 0, 1,
+		1, 0, 1, 0, 1, 0,
+		0x88, 0xa8,
+		0x00, 0x0a,
+		0x81, 0x00,
+		0x00, 0x14,
+		0x81, 0x00,
+		0x00, 0x1e,
+		0x08, 0x00,
+		0xde, 0xad,
+	}
+
+	var tests = []struct {
+		desc     string
+		d        Decoder
+		err      error
+		vlanIDs  []uint16
+		et       EtherType
+		payload  []byte
+		truncate bool
+	}{
+		{
+			desc:    "unlimited",
+			vlanIDs: []uint16{10, 20, 30},
+			et:      EtherTypeIPv4,
+			payload: []byte{0xde, 0xad},
+		},
+		{
+			desc:    "at limit",
+			d:       Decoder{MaxVLANTags: 3},
+			vlanIDs: []uint16{10, 20, 30},
+			et:      EtherTypeIPv4,
+			payload: []byte{0xde, 0xad},
+		},
+		{
+			desc: "exceeds limit",
+			d:    Decoder{MaxVLANTags: 2},
+			err:  ErrTooManyVLANs,
+		},
+		{
+			desc:     "exceeds limit, truncate",
+			d:        Decoder{MaxVLANTags: 2, TruncateVLANTags: true},
+			vlanIDs:  []uint16{10, 20},
+			et:       EtherTypeVLAN,
+			payload:  []byte{0x00, 0x1e, 0x08, 0x00, 0xde, 0xad},
+			truncate: true,
+		},
+		{
+			desc:     "exceeds limit, truncate, reuse",
+			d:        Decoder{MaxVLANTags: 1, TruncateVLANTags: true, ReuseVLANs: true},
+			vlanIDs:  []uint16{10},
+			et:       EtherTypeVLAN,
+			payload:  []byte{0x00, 0x14, 0x81, 0x00, 0x00, 0x1e, 0x08, 0x00, 0xde, 0xad},
+			truncate: true,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f := new(Frame)
+			err := tt.d.Decode(f, b)
+			if want, got := tt.err, err; want != got {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+			if err != nil {
+				return
+			}
+
+			var ids []uint16
+			for _, v := range f.VLAN {
+				ids = append(ids, v.ID)
+			}
+
+			if want, got := tt.vlanIDs, ids; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected VLAN IDs: %v != %v",
+					i, tt.desc, want, got)
+			}
+			if want, got := tt.et, f.EtherType; want != got {
+				t.Fatalf("[%02d] test %q, unexpected EtherType: %v != %v",
+					i, tt.desc, want, got)
+			}
+			if want, got := tt.payload, f.Payload; !bytes.Equal(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected payload: %v != %v",
+					i, tt.desc, want, got)
+			}
+			if want, got := tt.truncate, f.TagsTruncated; want != got {
+				t.Fatalf("[%02d] test %q, unexpected TagsTruncated: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			// A truncated Frame marshals to its original binary form.
+			mb, err := f.MarshalBinary()
+			if err != nil {
+				t.Fatalf("[%02d] test %q, failed to marshal Frame: %v", i, tt.desc, err)
+			}
+			if !bytes.Equal(b, mb[:len(b)]) {
+				t.Fatalf("[%02d] test %q, unexpected Frame bytes:\n- want: %v\n- got: %v",
+					i, tt.desc, b, mb[:len(b)])
+			}
+		})
+	}
+
+	// A subsequent Frame which is not truncated clears TagsTruncated.
+	d := Decoder{MaxVLANTags: 1, TruncateVLANTags: true}
+	f := new(Frame)
+	if err := d.Decode(f, b); err != nil {
+		t.Fatalf("failed to decode Frame: %v", err)
+	}
+	if err := d.Decode(f, TestVectors[0].Bytes); err != nil {
+		t.Fatalf("failed to decode Frame: %v", err)
+	}
+	if f.TagsTruncated {
+		t.Fatal("TagsTruncated was not cleared")
+	}
+}
+
+func TestDecoderTrailerLength(t *testing.T) {
+	want := &Frame{
+		Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+		Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+		VLAN:        []*VLAN{{ID: 10}},
+		EtherType:   EtherTypeIPv4,
+		Payload:     bytes.Repeat([]byte{0xaa}, 50),
+		Trailer:     []byte{0xca, 0xfe},
+	}
+
+	b, err := want.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+
+	d := Decoder{TrailerLength: 2}
+	f := new(Frame)
+	if err := d.Decode(f, b); err != nil {
+		t.Fatalf("failed to decode Frame: %v", err)
+	}
+	if !want.Equal(f) {
+		t.Fatalf("unexpected Frame:\n- want: %v\n- got: %v", want, f)
+	}
+
+	// The trailer must not alias the input.
+	b[len(b)-1] = 0xff
+	if want, got := byte(0xfe), f.Trailer[1]; want != got {
+		t.Fatalf("trailer aliases input: %#02x != %#02x", want, got)
+	}
+
+	// Without the option, the trailer remains in the payload, and a
+	// previous trailer is cleared.
+	if err := new(Decoder).Decode(f, b); err != nil {
+		t.Fatalf("failed to decode Frame: %v", err)
+	}
+	if f.Trailer != nil {
+		t.Fatalf("unexpected trailer: %v", f.Trailer)
+	}
+	if want, got := 52, len(f.Payload); want != got {
+		t.Fatalf("unexpected payload length: %v != %v", want, got)
+	}
+
+	// A byte slice too short for a header and trailer is an error.
+	d = Decoder{TrailerLength: 4}
+	if err := d.Decode(f, b[:17]); err != io.ErrUnexpectedEOF {
+		t.Fatalf("unexpected error: %v != %v", io.ErrUnexpectedEOF, err)
+	}
+	if want, got := uint64(1), d.Stats().Errors; want != got {
+		t.Fatalf("unexpected number of errors: %v != %v", want, got)
+	}
+}
+
+func TestDecoderReuseVLANsError(t *testing.T) {
+	good := &Frame{
+		Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+		Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+		VLAN:        []*VLAN{{ID: 100}, {ID: 200}},
+		EtherType:   EtherTypeIPv4,
+		Payload:     bytes.Repeat([]byte{0xaa}, 50),
+	}
+
+	gb, err := good.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+
+	var tests = []struct {
+		desc string
+		b    []byte
+		err  error
+	}{
+		{
+			desc: "truncated after first tag",
+			b: []byte{
+				0, 1, 0, 1, 0, 1,
+				1, 0, 1, 0, 1, 0,
+				0x81, 0x00, 0x00, 0x65,
+				0x81, 0x00,
+			},
+			err: io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "invalid second tag",
+			b: []byte{
+				0, 1, 0, 1, 0, 1,
+				1, 0, 1, 0, 1, 0,
+				0x81, 0x00, 0x00, 0x65,
+				0x81, 0x00, 0x0f, 0xff,
+				0x08, 0x00,
+			},
+			err: ErrInvalidVLAN,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			d := Decoder{ReuseVLANs: true}
+			f := new(Frame)
+			if err := d.Decode(f, gb); err != nil {
+				t.Fatalf("[%02d] test %q, failed to decode Frame: %v",
+					i, tt.desc, err)
+			}
+
+			if want, got := tt.err, d.Decode(f, tt.b); want != got {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			// The Frame, including its reused VLAN structs, still holds the
+			// previously decoded Frame.
+			if !good.Equal(f) {
+				t.Fatalf("[%02d] test %q, unexpected Frame:\n- want: %v\n- got: %v",
+					i, tt.desc, good, f)
+			}
+		})
+	}
+}
+
+func TestDecoderFCSTrailer(t *testing.T) {
+	var tests = []struct {
+		desc     string
+		excluded bool
+	}{
+		{
+			desc: "trailer covered",
+		},
+		{
+			desc:     "trailer excluded",
+			excluded: true,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			want := &Frame{
+				Destination:            net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:                 net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				EtherType:              EtherTypeIPv4,
+				Payload:                bytes.Repeat([]byte{0xaa}, 50),
+				Trailer:                []byte{0xca, 0xfe},
+				TrailerExcludedFromFCS: tt.excluded,
+			}
+
+			b, err := want.MarshalFCS()
+			if err != nil {
+				t.Fatalf("[%02d] test %q, failed to marshal Frame: %v",
+					i, tt.desc, err)
+			}
+
+			d := Decoder{
+				FCS:                    true,
+				TrailerLength:          2,
+				TrailerExcludedFromFCS: tt.excluded,
+			}
+
+			f := new(Frame)
+			if err := d.Decode(f, b); err != nil {
+				t.Fatalf("[%02d] test %q, failed to decode Frame: %v",
+					i, tt.desc, err)
+			}
+			if !reflect.DeepEqual(want, f) {
+				t.Fatalf("[%02d] test %q, unexpected Frame:\n- want: %v\n- got: %v",
+					i, tt.desc, want, f)
+			}
+
+			// The decoded Frame marshals to the same bytes.
+			fb, err := f.MarshalFCS()
+			if err != nil {
+				t.Fatalf("[%02d] test %q, failed to marshal Frame: %v",
+					i, tt.desc, err)
+			}
+			if !bytes.Equal(b, fb) {
+				t.Fatalf("[%02d] test %q, unexpected Frame bytes:\n- want: %v\n- got: %v",
+					i, tt.desc, b, fb)
+			}
+
+			// A Decoder which expects the other mode rejects the Frame.
+			d.TrailerExcludedFromFCS = !tt.excluded
+			if err := d.Decode(f, b); err != ErrInvalidFCS {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, ErrInvalidFCS, err)
+			}
+		})
+	}
+
+	d := Decoder{FCS: true}
+	if err := d.Decode(new(Frame), []byte{0, 1, 2}); err != io.ErrUnexpectedEOF {
+		t.Fatalf("unexpected error: %v != %v", io.ErrUnexpectedEOF, err)
+	}
+}
+
+func TestDecoderKeepRaw(t *testing.T) {
+	b := append([]byte{0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0xd5}, TestVectors[2].Bytes...)
+
+	d := Decoder{StripPreamble: true, KeepRaw: true}
+	f := new(Frame)
+	if err := d.Decode(f, b); err != nil {
+		t.Fatalf("failed to decode Frame: %v", err)
+	}
+
+	if !f.Equal(TestVectors[2].Frame) {
+		t.Fatalf("unexpected Frame:\n- want: %v\n- got: %v", TestVectors[2].Frame, f)
+	}
+
+	// The raw bytes include the preamble.
+	if want, got := b, f.Raw; !bytes.Equal(want, got) {
+		t.Fatalf("unexpected raw bytes:\n- want: %v\n- got: %v", want, got)
+	}
+
+	if err := new(Decoder).Decode(f, TestVectors[0].Bytes); err != nil {
+		t.Fatalf("failed to decode Frame: %v", err)
+	}
+	if f.Raw != nil {
+		t.Fatalf("unexpected raw bytes: %v", f.Raw)
+	}
+}
+
+func TestDecoderDecodeAll(t *testing.T) {
+	b, err := (&Frame{
+		Destination: Broadcast,
+		Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+		EtherType:   EtherTypeIPv4,
+	}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+
+	lldp, err := (&Frame{
+		Destination: net.HardwareAddr{0x01, 0x80, 0xc2, 0x00, 0x00, 0x0e},
+		Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+		EtherType:   EtherTypeLLDP,
+	}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+
+	var tests = []struct {
+		desc string
+		d    Decoder
+		bs   [][]byte
+		n    int
+		err  error
+		msg  string
+	}{
+		{
+			desc: "empty",
+		},
+		{
+			desc: "unlimited",
+			bs:   [][]byte{b, b, b},
+			n:    3,
+		},
+		{
+			desc: "exactly at limit",
+			d:    Decoder{MaxTotalBytes: 3 * len(b)},
+			bs:   [][]byte{b, b, b},
+			n:    3,
+		},
+		{
+			desc: "limit exceeded",
+			d:    Decoder{MaxTotalBytes: 3*len(b) - 1},
+			bs:   [][]byte{b, b, b},
+			n:    2,
+			err:  ErrMemoryLimit,
+			msg:  "decoder memory limit exceeded: 179 byte limit reached at input 2 after decoding 2 frames",
+		},
+		{
+			desc: "limit exceeded, KeepRaw",
+			d:    Decoder{MaxTotalBytes: 3 * len(b), KeepRaw: true},
+			bs:   [][]byte{b, b, b},
+			n:    1,
+			err:  ErrMemoryLimit,
+		},
+		{
+			desc: "decode error",
+			bs:   [][]byte{b, b[:10], b},
+			n:    1,
+			err:  io.ErrUnexpectedEOF,
+			msg:  "input 1 after decoding 1 frames: unexpected EOF",
+		},
+		{
+			desc: "dropped Frame skipped",
+			d:    Decoder{DropReservedMulticast: true},
+			bs:   [][]byte{b, lldp, b},
+			n:    2,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			frames, err := tt.d.DecodeAll(tt.bs)
+			if want, got := tt.err, err; !errors.Is(got, want) {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+			if err != nil && tt.msg != "" {
+				if want, got := tt.msg, err.Error(); want != got {
+					t.Fatalf("[%02d] test %q, unexpected error message: %q != %q",
+						i, tt.desc, want, got)
+				}
+			}
+
+			if want, got := tt.n, len(frames); want != got {
+				t.Fatalf("[%02d] test %q, unexpected number of Frames: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			// Each Frame must be distinct, and decoded from its input.
+			for j, f := range frames {
+				fb, err := f.MarshalBinary()
+				if err != nil {
+					t.Fatalf("[%02d] test %q, failed to marshal Frame %d: %v",
+						i, tt.desc, j, err)
+				}
+
+				if want, got := b, fb; !bytes.Equal(want, got) {
+					t.Fatalf("[%02d] test %q, unexpected Frame %d bytes:\n- want: %v\n-  got: %v",
+						i, tt.desc, j, want, got)
+				}
+				if j > 0 && f == frames[j-1] {
+					t.Fatalf("[%02d] test %q, Frame %d reused", i, tt.desc, j)
+				}
+			}
+
+			if want, got := uint64(tt.n), tt.d.Stats().Frames; want != got {
+				t.Fatalf("[%02d] test %q, unexpected decoded Frames stat: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
@@ -1,21 +1,51 @@
 // Package ethernet implements marshaling and unmarshaling of IEEE 802.3
 // Ethernet II frames and IEEE 802.10 VLAN tags.
+//
+// All multi-byte fields, such as EtherTypes, VLAN tag protocol identifiers,
+// VLAN tag control information, and frame check sequences, are marshaled
+// and unmarshaled in network byte order (big-endian).
 package ethernet
 
 import (
+	"bytes"
+	"encoding"
 	"encoding/binary"
 	"errors"
+	"fmt"
+	"hash"
 	"hash/crc32"
+	"hash/fnv"
 	"io"
+	"math"
 	"net"
+	"strings"
+	"time"
 )
 
 //go:generate stringer -output=string.go -type=EtherType
 
+// Ethernet frame and payload sizes. Frame sizes include the hardware
+// addresses, any VLAN tags, the EtherType, the payload, and the 4-byte frame
+// check sequence.
 const (
-	// minPayload is the minimum payload size for an Ethernet frame, assuming
-	// that no 802.1Q VLAN tags are present
-	minPayload = 46
+	// MinPayload is the minimum payload size for an Ethernet frame, assuming
+	// that no 802.1Q VLAN tags are present.
+	MinPayload = 46
+
+	// MinFrameSize is the minimum size of an Ethernet frame.
+	MinFrameSize = 64
+
+	// MaxFrameSize is the maximum size of an untagged Ethernet frame with
+	// a 1500 byte payload.
+	MaxFrameSize = 1518
+
+	// MaxFrameSizeVLAN is the maximum size of an Ethernet frame with a
+	// single 802.1Q VLAN tag and a 1500 byte payload.
+	MaxFrameSizeVLAN = MaxFrameSize + 4
+
+	// MaxFrameSizeQinQ is the maximum size of an Ethernet frame with two
+	// stacked 802.1Q VLAN tags and a 1500 byte payload.
+	MaxFrameSizeQinQ = MaxFrameSize + 8
 )
 
 var (
@@ -26,6 +56,32 @@ var (
 	// ErrInvalidFCS is returned when Frame.UnmarshalFCS detects an incorrect
 	// Ethernet frame check sequence in a byte slice for a Frame.
 	ErrInvalidFCS = errors.New("invalid frame check sequence")
+
+	// ErrInvalidEtherType is returned when a Frame's EtherType is not
+	// permitted, such as when it is in the undefined range of 1501 to 1535
+	// and UndefinedRange is set to UndefinedRangeError.
+	ErrInvalidEtherType = errors.New("invalid EtherType")
+
+	// ErrBufferTooSmall is returned when a byte slice is too small to hold
+	// the binary form of a Frame.
+	ErrBufferTooSmall = errors.New("buffer too small for frame")
+
+	// ErrInvalidOffset is returned by UnmarshalBinaryAt when an offset is
+	// outside the bounds of a byte slice.
+	ErrInvalidOffset = errors.New("invalid offset")
+
+	// ErrFrameTooLarge is returned when a Frame is larger than a size limit:
+	// by Frame.MarshalForInterface when a Frame does not fit within an
+	// interface's MTU, and by Frame.PadTo when a Frame is already larger than
+	// the requested length.
+	ErrFrameTooLarge = errors.New("frame too large")
+)
+
+// Compile-time assertions that Frame implements the binary encoding
+// interfaces.
+var (
+	_ encoding.BinaryMarshaler   = (*Frame)(nil)
+	_ encoding.BinaryUnmarshaler = (*Frame)(nil)
 )
 
 // An EtherType is a value used to identify an upper layer protocol
@@ -34,12 +90,139 @@ type EtherType uint16
 
 // CommonEtherType values frequently used in a Frame
 const (
-	EtherTypeIPv4 EtherType = 0x0800
-	EtherTypeARP  EtherType = 0x0806
-	EtherTypeVLAN EtherType = 0x8100
-	EtherTypeIPv6 EtherType = 0x86DD
+	EtherTypeIPv4                EtherType = 0x0800
+	EtherTypeARP                 EtherType = 0x0806
+	EtherTypeTransparentBridging EtherType = 0x6558
+	EtherTypeVLAN                EtherType = 0x8100
+	EtherTypeIPv6                EtherType = 0x86DD
+	EtherTypeMACControl          EtherType = 0x8808
+	EtherTypeSlowProtocols       EtherType = 0x8809
+	EtherTypeServiceVLAN         EtherType = 0x88A8
+	EtherTypeLocalExperimental1  EtherType = 0x88B5
+	EtherTypeLocalExperimental2  EtherType = 0x88B6
+	EtherTypeLLDP                EtherType = 0x88CC
+	EtherTypeITag                EtherType = 0x88E7
+	EtherTypeQinQ                EtherType = 0x9100
+	EtherTypeQinQAlt             EtherType = 0x9200
 )
 
+// knownEtherTypes lists each EtherType constant defined above, sorted by
+// value, for KnownEtherTypes. New constants must be added here as well.
+var knownEtherTypes = []EtherType{
+	EtherTypeIPv4,
+	EtherTypeARP,
+	EtherTypeTransparentBridging,
+	EtherTypeVLAN,
+	EtherTypeIPv6,
+	EtherTypeMACControl,
+	EtherTypeSlowProtocols,
+	EtherTypeServiceVLAN,
+	EtherTypeLocalExperimental1,
+	EtherTypeLocalExperimental2,
+	EtherTypeLLDP,
+	EtherTypeITag,
+	EtherTypeQinQ,
+	EtherTypeQinQAlt,
+}
+
+// undefined reports whether an EtherType is in the range 1501 to 1535
+// (0x05dd to 0x05ff), which is too large to be an IEEE 802.3 length, but too
+// small to be an EtherType.
+func (e EtherType) undefined() bool {
+	return e > 1500 && e < 1536
+}
+
+// IsExperimental reports whether an EtherType is reserved by IEEE Std 802
+// for local experimental use: EtherTypeLocalExperimental1 (0x88b5) or
+// EtherTypeLocalExperimental2 (0x88b6). Such EtherTypes are not assigned to
+// any protocol, and must not be used in products.
+func (e EtherType) IsExperimental() bool {
+	return e == EtherTypeLocalExperimental1 || e == EtherTypeLocalExperimental2
+}
+
+// KnownEtherTypes returns each EtherType constant defined by this package,
+// such as EtherTypeIPv4, sorted by value. It is useful for presenting the
+// EtherTypes known to this package, such as in a user interface. A new slice
+// is returned on each call, so it may be modified by the caller.
+func KnownEtherTypes() []EtherType {
+	return append([]EtherType(nil), knownEtherTypes...)
+}
+
+// EqualName reports whether s names an EtherType, ignoring case, for lenient
+// matching of user input such as command line flags. s may be the name of an
+// EtherType constant defined by this package, such as "EtherTypeIPv4", or
+// that name without its "EtherType" prefix, as returned by Frame.Protocol,
+// such as "IPv4", "ipv4", or "ARP".
+//
+// An EtherType which is not known to this package has no name, so EqualName
+// always reports false for it.
+func (e EtherType) EqualName(s string) bool {
+	name := e.String()
+	if strings.HasPrefix(name, "EtherType(") {
+		return false
+	}
+
+	return strings.EqualFold(s, name) ||
+		strings.EqualFold(s, strings.TrimPrefix(name, "EtherType"))
+}
+
+// An UndefinedRangePolicy specifies how a Frame is unmarshaled when its
+// EtherType field is in the range 1501 to 1535, which is undefined: it
+// is neither a valid IEEE 802.3 length nor a valid EtherType.
+type UndefinedRangePolicy int
+
+// UndefinedRangePolicy values which may be assigned to UndefinedRange.
+const (
+	// UndefinedRangeEtherType treats the field as an EtherType. This is
+	// the default.
+	UndefinedRangeEtherType UndefinedRangePolicy = iota
+
+	// UndefinedRangeLength treats the field as the length of the payload.
+	// The payload is truncated to that length, and io.ErrUnexpectedEOF is
+	// returned if not enough data is present. The EtherType field of the
+	// Frame is set to the length.
+	UndefinedRangeLength
+
+	// UndefinedRangeError rejects the Frame with ErrInvalidEtherType.
+	UndefinedRangeError
+)
+
+// VLANTPIDs is the set of tag protocol identifiers which indicate that a
+// VLAN tag, rather than an EtherType, follows the hardware addresses or a
+// previous VLAN tag when a Frame is unmarshaled. By default, the standard
+// IEEE 802.1Q and 802.1ad identifiers are recognized, as well as the legacy
+// Q-in-Q identifiers 0x9100 and 0x9200 used by older switches.
+//
+// Because EtherTypeServiceVLAN is recognized by default, an IEEE 802.1ad
+// Frame is unmarshaled with its outer tag in the VLAN field. Remove
+// EtherTypeServiceVLAN from VLANTPIDs to unmarshal such a Frame with
+// EtherType EtherTypeServiceVLAN and its tags in its Payload instead.
+//
+// VLANTPIDs must not be modified while Frames are being unmarshaled
+// concurrently.
+var VLANTPIDs = []EtherType{
+	EtherTypeVLAN,
+	EtherTypeServiceVLAN,
+	EtherTypeQinQ,
+	EtherTypeQinQAlt,
+}
+
+// isVLANTPID reports whether an EtherType is present in VLANTPIDs.
+func (e EtherType) isVLANTPID() bool {
+	for _, tpid := range VLANTPIDs {
+		if e == tpid {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UndefinedRange is the UndefinedRangePolicy consulted when a Frame is
+// unmarshaled. It must not be modified while Frames are being unmarshaled
+// concurrently.
+var UndefinedRange = UndefinedRangeEtherType
+
 // A Frame is an IEEE 802.3 Ethernet II frame. A Frame contains information
 // such as source and destination hardware addresses, zero or more optional 802.1Q
 // VLAN tags, an EtherType, and payload data.
@@ -56,7 +239,8 @@ type Frame struct {
 
 	// Vlan specifies one or more optional 802.1Q VLAN tags, which many or may
 	// not be present in a Frame. If no VLAN tags are present, this length of
-	// the slice will be 0.
+	// the slice will be 0. Tags are ordered from outermost to innermost, and
+	// any number of stacked tags may be marshaled and unmarshaled.
 	VLAN []*VLAN
 
 	// EtherType is a value used to identify an upper layer protocol
@@ -65,45 +249,433 @@ type Frame struct {
 
 	// Payload is a variable length data payload encapsulated by this Frame
 	Payload []byte
+
+	// PadByte specifies the value used to pad a Payload which is shorter
+	// than the minimum payload size when this Frame is marshaled. The
+	// default value of 0 pads with zero bytes.
+	//
+	// PadByte is not set when a Frame is unmarshaled, because padding
+	// cannot be distinguished from payload data.
+	PadByte byte
+
+	// MinSize optionally specifies the minimum size of this Frame's binary
+	// form, excluding any frame check sequence, when it is marshaled. If the
+	// header and payload are smaller than MinSize, the payload is padded
+	// with PadByte to reach it. For example, a MinSize of 60 targets the
+	// 64-byte minimum frame size once a frame check sequence is appended.
+	//
+	// MinSize can only increase the padding applied to a Frame: a payload is
+	// always padded to at least the minimum payload size of 46 bytes, so the
+	// default value of 0 pads as usual. MinSize is not set when a Frame is
+	// unmarshaled.
+	MinSize int
+
+	// NoPad specifies that this Frame's payload should not be padded when it
+	// is marshaled, even if it is shorter than the minimum payload size or
+	// MinSize, such as for protocols or test equipment which transmit runt
+	// frames deliberately. NoPad also permits MarshalStrict to marshal a
+	// Frame with a short payload. NoPad is not set when a Frame is
+	// unmarshaled.
+	NoPad bool
+
+	// LengthField specifies that the EtherType field of this Frame carries
+	// an IEEE 802.3 length, rather than an EtherType. LengthField permits
+	// MarshalStrict to marshal a Frame whose EtherType is 0, which is
+	// otherwise rejected as ambiguous. LengthField is not set when a Frame
+	// is unmarshaled.
+	LengthField bool
+
+	// Trailer optionally specifies vendor-specific data, such as a port tag,
+	// which follows the padded Payload in the binary form of this Frame.
+	// Trailer is not counted towards the minimum payload size or MinSize,
+	// so a Frame with a short Payload is padded before the Trailer is
+	// appended.
+	//
+	// When a Frame is marshaled with a frame check sequence, such as by
+	// MarshalFCS, the Trailer precedes the frame check sequence, and is
+	// covered by it unless TrailerExcludedFromFCS is set.
+	//
+	// Trailer cannot be distinguished from payload data, so it is only set
+	// when a Frame is unmarshaled by a Decoder with the TrailerLength option.
+	Trailer []byte
+
+	// TrailerExcludedFromFCS specifies that the frame check sequence of this
+	// Frame is computed over its header and padded payload only, as some
+	// devices do when they insert a trailer between the payload and the
+	// frame check sequence. The Trailer remains in place before the frame
+	// check sequence. TrailerExcludedFromFCS is set when a Frame with a
+	// Trailer is unmarshaled by a Decoder with the option of the same name.
+	TrailerExcludedFromFCS bool
+
+	// Raw optionally contains a copy of the exact bytes from which this
+	// Frame was unmarshaled, which may differ from its marshaled form, such
+	// as due to padding. Raw enables byte-exact replay of a Frame, such as
+	// for audit trails.
+	//
+	// Raw is only set by UnmarshalBinaryKeepRaw, and by a Decoder with the
+	// KeepRaw option; other methods which unmarshal a Frame set it to nil.
+	// Raw never aliases the input byte slice, and is ignored when a Frame is
+	// marshaled.
+	Raw []byte
+
+	// Timestamp optionally specifies the time at which this Frame was
+	// captured. Timestamp is not present in the binary form of a Frame: it
+	// is ignored when a Frame is marshaled, and is not set when a Frame is
+	// unmarshaled.
+	Timestamp time.Time
+
+	// TagsTruncated reports whether a Decoder with the MaxVLANTags and
+	// TruncateVLANTags options stopped parsing VLAN tags before the end of
+	// this Frame's header. If so, the tag protocol identifier of the first
+	// tag which was not parsed is stored in EtherType, and the remainder of
+	// that tag begins the Payload.
+	//
+	// TagsTruncated is ignored when a Frame is marshaled, so a truncated
+	// Frame marshals to its original binary form.
+	TagsTruncated bool
+
+	// Meta optionally carries arbitrary processing metadata, such as an
+	// ingress port or a forwarding decision, as a Frame travels through the
+	// stages of a pipeline. Meta is not part of the binary form of a Frame:
+	// it is ignored when a Frame is marshaled, and is not modified when a
+	// Frame is unmarshaled.
+	Meta map[string]interface{}
+
+	// addrs stores the hardware addresses set by SetDestinationArray and
+	// SetSourceArray, so that setting them does not allocate.
+	addrs [12]byte
 }
 
 // MarshalBinary allocates a byte slice and marshals a Frame into binary form.
+// Marshaling is deterministic: identical Frames always produce identical
+// bytes, and any padding is filled explicitly with PadByte.
 //
 // If one or more VLANs are set and their IDs are too large (greater than 4094),
 // or one or more VLANs' priority are too large (greater than 7),
 // ErrInvalidVLAN is returned
 func (f *Frame) MarshalBinary() ([]byte, error) {
-	b := make([]byte, f.length())
+	b := make([]byte, f.Length())
 	_, err := f.read(b)
 	return b, err
 }
 
+// MarshalBinaryTo marshals a Frame into binary form in b, without allocating,
+// and returns the number of bytes written.
+//
+// If b is shorter than the Frame's Length, an error wrapping
+// ErrBufferTooSmall is returned. MarshalBinaryTo otherwise returns the same
+// errors as MarshalBinary.
+func (f *Frame) MarshalBinaryTo(b []byte) (int, error) {
+	l := f.Length()
+	if len(b) < l {
+		return 0, fmt.Errorf("%w: need %d bytes, have %d", ErrBufferTooSmall, l, len(b))
+	}
+
+	return f.read(b[:l])
+}
+
 // MarshalFCS allocates a byte slice, marshals a Frame into binary form, and
 // finally calculates and places a 4-byte IEEE CRC32 frame check sequence at
 // the end of the slice
 func (f *Frame) MarshalFCS() ([]byte, error) {
 	// Frame length with 4 extra bytes for frame check sequence
-	b := make([]byte, f.length()+4)
+	b := make([]byte, f.Length()+4)
+	if _, err := f.read(b); err != nil {
+		return nil, err
+	}
+
+	binary.BigEndian.PutUint32(b[len(b)-4:], FCS(f.fcsBody(b[0:len(b)-4])))
+	return b, nil
+}
+
+// fcsBody returns the portion of b, the binary form of a Frame without its
+// frame check sequence, which is covered by the frame check sequence: all of
+// b, unless TrailerExcludedFromFCS is set, in which case the Trailer at the
+// end of b is excluded.
+func (f *Frame) fcsBody(b []byte) []byte {
+	if f.TrailerExcludedFromFCS {
+		return b[:len(b)-len(f.Trailer)]
+	}
+
+	return b
+}
+
+// FCS computes the 4-byte IEEE CRC32 frame check sequence of body, the binary
+// form of a Frame such as that produced by MarshalBinary. FCS is useful when
+// the binary form of a Frame is already available, and avoids marshaling
+// the Frame again with MarshalFCS.
+func FCS(body []byte) uint32 {
+	return crc32.ChecksumIEEE(body)
+}
+
+// AppendFCS appends the frame check sequence of body, as computed by FCS,
+// to body, and returns the resulting byte slice.
+func AppendFCS(body []byte) []byte {
+	var fcs [4]byte
+	binary.BigEndian.PutUint32(fcs[:], FCS(body))
+	return append(body, fcs[:]...)
+}
+
+// castagnoliTable is the CRC32 table for the Castagnoli polynomial, used by
+// IdentifyFCS.
+var castagnoliTable = crc32.MakeTable(crc32.Castagnoli)
+
+// IdentifyFCS reports whether the trailing 4 bytes of b are a valid frame
+// check sequence for the remainder of b, computed using the standard IEEE
+// CRC32 polynomial, or the Castagnoli CRC32C polynomial used by some
+// non-conforming hardware. IdentifyFCS is a diagnostic which helps classify
+// captures from mixed hardware; it does not unmarshal a Frame.
+//
+// If b is shorter than 4 bytes, both results are false.
+func IdentifyFCS(b []byte) (ieee bool, castagnoli bool) {
+	if len(b) < 4 {
+		return false, false
+	}
+
+	body := b[0 : len(b)-4]
+	want := binary.BigEndian.Uint32(b[len(b)-4:])
+
+	return want == crc32.ChecksumIEEE(body), want == crc32.Checksum(body, castagnoliTable)
+}
+
+// MarshalFCSHash allocates a byte slice, marshals a Frame into binary form,
+// and finally places a 4-byte frame check sequence computed by h at the end
+// of the slice.
+//
+// h is reset before use. MarshalFCSHash enables the use of alternative or
+// hardware-accelerated CRC32 implementations; MarshalFCS should be used
+// for the standard IEEE CRC32 frame check sequence.
+func (f *Frame) MarshalFCSHash(h hash.Hash32) ([]byte, error) {
+	// Frame length with 4 extra bytes for frame check sequence
+	b := make([]byte, f.Length()+4)
+	if _, err := f.read(b); err != nil {
+		return nil, err
+	}
+
+	h.Reset()
+	_, _ = h.Write(f.fcsBody(b[0 : len(b)-4]))
+	binary.BigEndian.PutUint32(b[len(b)-4:], h.Sum32())
+	return b, nil
+}
+
+// WriteFCS writes the binary form of a Frame to w, followed by its 4-byte IEEE
+// CRC32 frame check sequence, as produced by MarshalFCS. WriteFCS returns the
+// number of bytes written, including the frame check sequence, and the frame
+// check sequence itself, which is useful for senders which log it.
+//
+// The frame check sequence is computed as the Frame is written, and the
+// payload is not copied, so WriteFCS avoids allocating the complete binary
+// form of a Frame. If an error occurs, the frame check sequence is 0.
+//
+// If the Frame cannot be marshaled, WriteFCS returns the same errors as
+// MarshalBinary, and nothing is written.
+func (f *Frame) WriteFCS(w io.Writer) (n int64, fcs uint32, err error) {
+	bufs, err := f.Buffers()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// A Trailer which is excluded from the frame check sequence is written
+	// after the frame check sequence is computed.
+	var trailer net.Buffers
+	if f.TrailerExcludedFromFCS && len(f.Trailer) > 0 {
+		bufs, trailer = bufs[:len(bufs)-1], bufs[len(bufs)-1:]
+	}
+
+	cw := &crcWriter{
+		w: w,
+		h: crc32.NewIEEE(),
+	}
+	if _, err := bufs.WriteTo(cw); err != nil {
+		return cw.n, 0, err
+	}
+
+	fcs = cw.h.Sum32()
+	if _, err := trailer.WriteTo(cw); err != nil {
+		return cw.n, 0, err
+	}
+
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], fcs)
+	if _, err := cw.Write(b[:]); err != nil {
+		return cw.n, 0, err
+	}
+
+	return cw.n, fcs, nil
+}
+
+// A crcWriter is an io.Writer which computes a checksum of the bytes written
+// to an underlying io.Writer, and counts them.
+type crcWriter struct {
+	w io.Writer
+	h hash.Hash32
+	n int64
+}
+
+// Write implements io.Writer.
+func (cw *crcWriter) Write(b []byte) (int, error) {
+	n, err := cw.w.Write(b)
+	_, _ = cw.h.Write(b[:n])
+	cw.n += int64(n)
+	return n, err
+}
+
+// MarshalFCSUntagged is like MarshalFCS, but computes the frame check
+// sequence as if the Frame's VLAN tags were not present: the CRC32 covers
+// only the hardware addresses, EtherType, and padded payload. The VLAN tags
+// are still present in the returned byte slice.
+//
+// MarshalFCSUntagged is non-standard, and exists only for bug-for-bug
+// compatibility with devices which compute the frame check sequence in this
+// way. MarshalFCS should be used in all other cases.
+func (f *Frame) MarshalFCSUntagged() ([]byte, error) {
+	// Frame length with 4 extra bytes for frame check sequence
+	b := make([]byte, f.Length()+4)
+	if _, err := f.read(b); err != nil {
+		return nil, err
+	}
+
+	binary.BigEndian.PutUint32(b[len(b)-4:], untaggedChecksum(f.fcsBody(b[0:len(b)-4]), len(f.VLAN)))
+	return b, nil
+}
+
+// MarshalWithFCS allocates a byte slice, marshals a Frame into binary form,
+// and places the 4-byte frame check sequence fcs verbatim at the end of the
+// slice, without computing a CRC32.
+//
+// MarshalWithFCS is useful for reproducing captured frames with a known,
+// possibly incorrect, frame check sequence. MarshalFCS should be used to
+// compute a correct frame check sequence.
+func (f *Frame) MarshalWithFCS(fcs uint32) ([]byte, error) {
+	// Frame length with 4 extra bytes for frame check sequence
+	b := make([]byte, f.Length()+4)
+	if _, err := f.read(b); err != nil {
+		return nil, err
+	}
+
+	binary.BigEndian.PutUint32(b[len(b)-4:], fcs)
+	return b, nil
+}
+
+// MarshalBadFCS is like MarshalFCS, but places a deliberately incorrect
+// frame check sequence at the end of the slice: the correct IEEE CRC32 with
+// every bit inverted. The resulting frame is guaranteed to be rejected by
+// UnmarshalFCS with ErrInvalidFCS.
+//
+// MarshalBadFCS exists only for negative testing, such as exercising the
+// frame check sequence rejection path of a receiver, and must not be used
+// to produce frames for transmission in production.
+func (f *Frame) MarshalBadFCS() ([]byte, error) {
+	b := make([]byte, f.Length()+4)
 	if _, err := f.read(b); err != nil {
 		return nil, err
 	}
 
-	binary.BigEndian.PutUint32(b[len(b)-4:], crc32.ChecksumIEEE(b[0:len(b)-4]))
+	binary.BigEndian.PutUint32(b[len(b)-4:], FCS(f.fcsBody(b[0:len(b)-4]))^0xffffffff)
 	return b, nil
 }
 
+// MarshalHeader allocates a byte slice and marshals only the header of a
+// Frame into binary form: the destination and source hardware addresses,
+// any VLAN tags, and the EtherType. The payload is not included, and no
+// padding is applied.
+//
+// MarshalHeader is useful for precomputing a constant header to which
+// varying payloads are appended. The caller is responsible for padding
+// the resulting frame to the minimum size and computing a frame check
+// sequence, if needed.
+//
+// If one or more VLANs are set and their IDs are too large (greater than 4094),
+// or one or more VLANs' priority are too large (greater than 7),
+// ErrInvalidVLAN is returned
+func (f *Frame) MarshalHeader() ([]byte, error) {
+	b := make([]byte, f.HeaderOverhead())
+	if _, err := f.readHeader(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Buffers marshals a Frame into binary form as net.Buffers, for use with
+// scatter-gather I/O such as writev. The first buffer contains the Frame's
+// header, and the second is f.Payload itself, which is not copied. If the
+// payload is shorter than the minimum size, a buffer containing padding is
+// appended. If the Frame has a Trailer, the final buffer is f.Trailer itself,
+// which is not copied.
+//
+// The returned buffers alias f.Payload and f.Trailer, so they must not be
+// modified until the buffers have been written.
+//
+// Buffers returns the same errors as MarshalHeader.
+func (f *Frame) Buffers() (net.Buffers, error) {
+	pad := f.minPayload() - len(f.Payload)
+	if pad < 0 {
+		pad = 0
+	}
+
+	// Allocate the header and padding together, and split them apart.
+	b := make([]byte, f.HeaderOverhead()+pad)
+	n, err := f.readHeader(b)
+	if err != nil {
+		return nil, err
+	}
+
+	bufs := net.Buffers{b[:n:n]}
+	if len(f.Payload) > 0 {
+		bufs = append(bufs, f.Payload)
+	}
+	if pad > 0 {
+		p := b[n:]
+		for i := range p {
+			p[i] = f.PadByte
+		}
+
+		bufs = append(bufs, p)
+	}
+	if len(f.Trailer) > 0 {
+		bufs = append(bufs, f.Trailer)
+	}
+
+	return bufs, nil
+}
+
 // read reads data from a Frame into b. read is used to marshal a Frame
 // into a binary form, but does not allocate on its own
 func (f *Frame) read(b []byte) (int, error) {
-	copy(b[0:6], f.Destination)
-	copy(b[6:12], f.Source)
+	n, err := f.readHeader(b)
+	if err != nil {
+		return 0, err
+	}
 
-	// Marshal each VLAN tag into bytes, inserting a VLAN EtherType value
-	// before each, so device know that one or more VLANs are present.
+	// Copy payload into output bytes after the header, and fill any
+	// padding needed to reach the minimum payload size with PadByte.
+	n += copy(b[n:], f.Payload)
+	end := f.Length() - len(f.Trailer)
+	pad := b[n:end]
+	for i := range pad {
+		pad[i] = f.PadByte
+	}
+
+	copy(b[end:], f.Trailer)
+
+	return len(b), nil
+}
+
+// readHeader reads the header of a Frame into b, and returns the number of
+// bytes read. Every byte of the header is written, so that marshaling into a
+// reused buffer is deterministic.
+func (f *Frame) readHeader(b []byte) (int, error) {
+	readAddr(b[0:6], f.Destination)
+	readAddr(b[6:12], f.Source)
+
+	// Marshal each VLAN tag into bytes, inserting the tag's protocol
+	// identifier before each, so device know that one or more VLANs are
+	// present.
 	n := 12
 	for _, v := range f.VLAN {
-		// Add VLAN EtherType and VLAN bytes
-		binary.BigEndian.PutUint16(b[n:n+2], uint16(EtherTypeVLAN))
+		// Add VLAN TPID and VLAN bytes
+		binary.BigEndian.PutUint16(b[n:n+2], uint16(v.tpid()))
 
 		if _, err := v.read(b[n+2 : n+4]); err != nil {
 			return 0, err
@@ -112,16 +684,24 @@ func (f *Frame) read(b []byte) (int, error) {
 		n += 4
 	}
 
-	// Marshal actual EtherType after any VLANs, copy payload into
-	// output bytes.
-	// TODO why not copy here?
+	// Marshal actual EtherType after any VLANs.
 	binary.BigEndian.PutUint16(b[n:n+2], uint16(f.EtherType))
-	copy(b[n+2:], f.Payload)
 
-	return len(b), nil
+	return n + 2, nil
+}
+
+// readAddr copies a hardware address into b, and zeroes any bytes of b which
+// follow a nil or short address, rather than leaving their previous contents.
+func readAddr(b []byte, addr net.HardwareAddr) {
+	n := copy(b, addr)
+	for i := range b[n:] {
+		b[n+i] = 0
+	}
 }
 
-// UnmarshalBinary unmarshals a byte slice into a Frame
+// UnmarshalBinary unmarshals a byte slice into a Frame. A Frame may be
+// reused for multiple calls: any VLAN tags it contains are replaced, and the
+// VLAN structs they reference are not modified.
 //
 // If the byte slice does not contain enough data to unmarshal a valid Frame,
 // io.ErrUnexpectedEOF is returned.
@@ -129,37 +709,220 @@ func (f *Frame) read(b []byte) (int, error) {
 // If one or more VLANs are detected and their IDs are too large (greater than
 // 4094), ErrInvalidVLAN is returned
 func (f *Frame) UnmarshalBinary(b []byte) error {
-	// Verify that both hardware addresses and a single EtherType are present
-	if len(b) < 14 {
-		return io.ErrUnexpectedEOF
+	return f.unmarshalBinary(b, unmarshalOptions{})
+}
+
+// UnmarshalBinaryKeepRaw is like UnmarshalBinary, but also stores a copy of
+// b in the Frame's Raw field, so that the exact bytes may be re-emitted
+// later without marshaling the Frame again.
+//
+// UnmarshalBinaryKeepRaw returns the same errors as UnmarshalBinary.
+func (f *Frame) UnmarshalBinaryKeepRaw(b []byte) error {
+	if err := f.UnmarshalBinary(b); err != nil {
+		return err
+	}
+
+	f.Raw = append([]byte(nil), b...)
+	return nil
+}
+
+// unmarshalOptions specifies options for unmarshaling a Frame, which are
+// set by a Decoder.
+type unmarshalOptions struct {
+	headerOptions
+
+	// truncateTags specifies that VLAN tags in excess of maxTags should be
+	// left in the payload, rather than returning ErrTooManyVLANs.
+	truncateTags bool
+}
+
+// unmarshalBinary implements UnmarshalBinary, according to opts.
+func (f *Frame) unmarshalBinary(b []byte, opts unmarshalOptions) error {
+	n, end, err := f.unmarshalHeader(b, opts)
+	if err != nil {
+		return err
+	}
+
+	f.copyAddrsAndPayload(b[:end], n)
+	return nil
+}
+
+// UnmarshalBinaryZeroCopy unmarshals a byte slice into a Frame without
+// copying any data: the Destination, Source, and Payload fields of the
+// Frame point directly into b.
+//
+// UnmarshalBinaryZeroCopy avoids an allocation and copy for each Frame,
+// but the caller must not modify or reuse b until it is done with the
+// Frame, and modifications to the Frame's fields will modify b. When in
+// doubt, use UnmarshalBinary instead.
+//
+// UnmarshalBinaryZeroCopy returns the same errors as UnmarshalBinary.
+func (f *Frame) UnmarshalBinaryZeroCopy(b []byte) error {
+	n, end, err := f.unmarshalHeader(b, unmarshalOptions{})
+	if err != nil {
+		return err
 	}
 
-	// Track offset in packet for reading data
-	n := 14
+	// Cap each slice so that an append cannot overwrite adjacent fields.
+	f.Destination = b[0:6:6]
+	f.Source = b[6:12:12]
+	f.Payload = b[n:end:end]
+	return nil
+}
+
+// UnmarshalBinaryAddrView unmarshals a byte slice into a Frame, copying
+// the payload, but not the hardware addresses: the Destination and Source
+// fields of the Frame point directly into b.
+//
+// UnmarshalBinaryAddrView is a middle ground between UnmarshalBinary and
+// UnmarshalBinaryZeroCopy for callers which inspect the hardware addresses
+// immediately, but retain the payload. The caller must not modify or reuse
+// b while it uses the Frame's hardware addresses.
+//
+// UnmarshalBinaryAddrView returns the same errors as UnmarshalBinary.
+func (f *Frame) UnmarshalBinaryAddrView(b []byte) error {
+	n, end, err := f.unmarshalHeader(b, unmarshalOptions{})
+	if err != nil {
+		return err
+	}
 
-	// Continue looping and parsing VLAN tags until no more VLAN EtherType
-	// values are detected
-	et := EtherType(binary.BigEndian.Uint16(b[n-2 : n]))
-	for ; et == EtherTypeVLAN; n += 4 {
-		// 4 or more bytes must remain for valid VLAN tag and EtherType
-		if len(b[n:]) < 4 {
-			return io.ErrUnexpectedEOF
+	// Cap each slice so that an append cannot overwrite adjacent fields.
+	f.Destination = b[0:6:6]
+	f.Source = b[6:12:12]
+	f.Payload = make([]byte, end-n)
+	copy(f.Payload, b[n:end])
+	return nil
+}
+
+// unmarshalHeader unmarshals any VLAN tags and the EtherType from b into
+// a Frame, according to opts, and returns the offsets of the beginning and
+// end of the payload in b.
+func (f *Frame) unmarshalHeader(b []byte, opts unmarshalOptions) (int, int, error) {
+	// Discard any VLAN tags from a Frame which was previously unmarshaled,
+	// so that they do not accumulate.
+	var vlans []*VLAN
+	if opts.reuse {
+		vlans = f.VLAN[:0]
+
+		// Verify the tags before the VLAN structs of the Frame are
+		// overwritten, so that the Frame is not modified if they are
+		// invalid.
+		err := checkTags(b, opts.maxTags)
+		if err != nil && !(err == ErrTooManyVLANs && opts.truncateTags) {
+			return 0, 0, err
 		}
+	}
 
-		// Body of VLAN tag is 2 bytes in length;
-		vlan := new(VLAN)
+	h, n, err := parseHeader(b, vlans, opts.headerOptions)
+	truncated := err == ErrTooManyVLANs && opts.truncateTags
+	if err != nil && !truncated {
+		return 0, 0, err
+	}
+
+	end, err := payloadEnd(b, n, h.EtherType)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	f.resetUnmarshaled()
+	f.VLAN = h.VLAN
+	f.EtherType = h.EtherType
+	f.TagsTruncated = truncated
+
+	return n, end, nil
+}
+
+// payloadEnd returns the offset of the end of a payload which begins at
+// offset n of b, and follows EtherType e, by applying the UndefinedRange
+// policy to values which are neither a valid length nor a valid EtherType.
+func payloadEnd(b []byte, n int, e EtherType) (int, error) {
+	if !e.undefined() {
+		return len(b), nil
+	}
+
+	switch UndefinedRange {
+	case UndefinedRangeLength:
+		end := n + int(e)
+		if end > len(b) {
+			return 0, io.ErrUnexpectedEOF
+		}
+		return end, nil
+	case UndefinedRangeError:
+		return 0, ErrInvalidEtherType
+	}
+
+	return len(b), nil
+}
+
+// resetUnmarshaled clears the fields of a Frame which are only set by some
+// methods which unmarshal a Frame, so that they do not persist when the
+// Frame is reused with another method.
+func (f *Frame) resetUnmarshaled() {
+	f.Trailer = nil
+	f.TrailerExcludedFromFCS = false
+	f.Raw = nil
+	f.TagsTruncated = false
+}
+
+// UnmarshalBinaryNTags unmarshals a byte slice into a Frame, parsing exactly
+// nTags VLAN tags after the hardware addresses, regardless of the tag
+// protocol identifier present before each tag.
+//
+// UnmarshalBinaryNTags is useful when the number of VLAN tags is known
+// out-of-band, such as when non-standard or ambiguous tag protocol
+// identifiers are in use. UnmarshalBinary should be used otherwise.
+//
+// If the byte slice does not contain enough data for the addresses, nTags
+// VLAN tags, and an EtherType, io.ErrUnexpectedEOF is returned. If nTags
+// is negative, or one or more VLANs are invalid, ErrInvalidVLAN is returned.
+// The UndefinedRange policy is applied as it is by UnmarshalBinary.
+func (f *Frame) UnmarshalBinaryNTags(b []byte, nTags int) error {
+	if nTags < 0 {
+		return ErrInvalidVLAN
+	}
+
+	// Verify that both hardware addresses, all VLAN tags, and a single
+	// EtherType are present
+	if len(b) < 14+(4*nTags) {
+		return io.ErrUnexpectedEOF
+	}
 
-		if err := vlan.UnmarshalBinary(b[n : n+2]); err != nil {
+	vlans := make([]*VLAN, 0, nTags)
+	n := 12
+	for i := 0; i < nTags; i++ {
+		// The tag protocol identifier is not checked, but is preserved
+		// along with the tag body
+		vlan := new(VLAN)
+		if err := vlan.UnmarshalBinary(b[n+2 : n+4]); err != nil {
 			return err
 		}
-		f.VLAN = append(f.VLAN, vlan)
+		vlan.setTPID(EtherType(binary.BigEndian.Uint16(b[n : n+2])))
+		vlans = append(vlans, vlan)
+
+		n += 4
+	}
+
+	et := EtherType(binary.BigEndian.Uint16(b[n : n+2]))
+	end, err := payloadEnd(b, n+2, et)
+	if err != nil {
+		return err
+	}
 
-		// Parse next tag to determine if it is another VLAN, or if not,
-		// break the loop
-		et = EtherType(binary.BigEndian.Uint16(b[n+2 : n+4]))
+	f.resetUnmarshaled()
+	if nTags > 0 {
+		f.VLAN = vlans
+	} else {
+		f.VLAN = nil
 	}
+
 	f.EtherType = et
+	f.copyAddrsAndPayload(b[:end], n+2)
+	return nil
+}
 
+// copyAddrsAndPayload copies the hardware addresses at the beginning of b,
+// and the payload beginning at offset n of b, into a Frame.
+func (f *Frame) copyAddrsAndPayload(b []byte, n int) {
 	// Allocate single byte slice to store destination and source hardware
 	// addresses, and payload
 	bb := make([]byte, 6+6+len(b[n:]))
@@ -174,8 +937,83 @@ func (f *Frame) UnmarshalBinary(b []byte) error {
 	// follow the "robustness principle".
 	copy(bb[12:], b[n:])
 	f.Payload = bb[12:]
+}
 
-	return nil
+// UnmarshalPartial unmarshals as much of a byte slice into a Frame as
+// possible, and returns the Frame and the number of bytes consumed.
+//
+// If the byte slice contains a complete Frame, UnmarshalPartial behaves
+// like Frame.UnmarshalBinary and consumes the entire slice.
+//
+// If the byte slice is truncated, each field which is fully present
+// (destination, source, and any complete VLAN tags) is decoded, and
+// io.ErrUnexpectedEOF is returned along with the partially decoded Frame.
+// This is useful for analyzing truncated captures.
+func UnmarshalPartial(b []byte) (*Frame, int, error) {
+	f := new(Frame)
+	err := f.UnmarshalBinary(b)
+	if err == nil {
+		return f, len(b), nil
+	}
+	if err != io.ErrUnexpectedEOF {
+		return nil, 0, err
+	}
+
+	// The Frame is truncated, so decode each field only if enough bytes
+	// remain for it.
+	f = new(Frame)
+	if len(b) < 6 {
+		return f, 0, io.ErrUnexpectedEOF
+	}
+	f.Destination = make(net.HardwareAddr, 6)
+	copy(f.Destination, b[0:6])
+
+	if len(b) < 12 {
+		return f, 6, io.ErrUnexpectedEOF
+	}
+	f.Source = make(net.HardwareAddr, 6)
+	copy(f.Source, b[6:12])
+
+	// Any complete VLAN tags are decoded; a trailing EtherType cannot be
+	// present, or UnmarshalBinary would have succeeded.
+	n := 12
+	for len(b[n:]) >= 4 {
+		tpid := EtherType(binary.BigEndian.Uint16(b[n : n+2]))
+		if !tpid.isVLANTPID() {
+			break
+		}
+
+		vlan := new(VLAN)
+		if err := vlan.UnmarshalBinary(b[n+2 : n+4]); err != nil {
+			return f, n, err
+		}
+		vlan.setTPID(tpid)
+		f.VLAN = append(f.VLAN, vlan)
+
+		n += 4
+	}
+
+	return f, n, io.ErrUnexpectedEOF
+}
+
+// UnmarshalBinaryAt unmarshals a Frame which begins at offset in b, such as a
+// Frame embedded in the payload of another packet, and which extends to the
+// end of b.
+//
+// If offset is negative or greater than the length of b, an error wrapping
+// ErrInvalidOffset is returned. UnmarshalBinaryAt otherwise returns the same
+// errors as Frame.UnmarshalBinary.
+func UnmarshalBinaryAt(b []byte, offset int) (*Frame, error) {
+	if offset < 0 || offset > len(b) {
+		return nil, fmt.Errorf("%w: %d not in range [0, %d]", ErrInvalidOffset, offset, len(b))
+	}
+
+	f := new(Frame)
+	if err := f.UnmarshalBinary(b[offset:]); err != nil {
+		return nil, err
+	}
+
+	return f, nil
 }
 
 // UnmarshalFCS computes the IEEE CRC32 frame check sequence of a Frame,
@@ -187,20 +1025,564 @@ func (f *Frame) UnmarshalFCS(b []byte) error {
 		return io.ErrUnexpectedEOF
 	}
 
+	if err := verifyFCS(b); err != nil {
+		return err
+	}
+
+	return f.UnmarshalBinary(b[0 : len(b)-4])
+}
+
+// verifyFCS verifies the IEEE CRC32 frame check sequence at the end of b,
+// which must contain at least 4 bytes.
+func verifyFCS(b []byte) error {
+	want := binary.BigEndian.Uint32(b[len(b)-4:])
+	if want != crc32.ChecksumIEEE(b[0:len(b)-4]) {
+		return ErrInvalidFCS
+	}
+
+	return nil
+}
+
+// UnmarshalAuto unmarshals a byte slice which may or may not end with a
+// 4-byte IEEE CRC32 frame check sequence, and reports whether one was
+// present.
+//
+// If b is at least MinFrameSize bytes in length and its final 4 bytes are a
+// valid frame check sequence for the preceding bytes, b is unmarshaled as by
+// Frame.UnmarshalFCS, and true is returned. Otherwise, b is unmarshaled as by
+// Frame.UnmarshalBinary, and the final 4 bytes are treated as payload.
+//
+// Detection is probabilistic: a Frame without a frame check sequence whose
+// final 4 bytes happen to match the CRC32 of its other bytes is reported as
+// having one, with a probability of about 1 in 2^32 for arbitrary data.
+// Frames shorter than MinFrameSize are never considered to have a frame
+// check sequence, because a transmitted Frame which includes one is always
+// padded to at least that size.
+func UnmarshalAuto(b []byte) (*Frame, bool, error) {
+	f := new(Frame)
+	if len(b) >= MinFrameSize {
+		err := f.UnmarshalFCS(b)
+		switch err {
+		case nil:
+			return f, true, nil
+		case ErrInvalidFCS:
+			// No frame check sequence; fall back to UnmarshalBinary.
+		default:
+			return nil, false, err
+		}
+	}
+
+	if err := f.UnmarshalBinary(b); err != nil {
+		return nil, false, err
+	}
+
+	return f, false, nil
+}
+
+// UnmarshalFCSUntagged is like UnmarshalFCS, but verifies a frame check
+// sequence which was computed as if the Frame's VLAN tags were not present,
+// as produced by MarshalFCSUntagged.
+//
+// UnmarshalFCSUntagged is non-standard, and exists only for bug-for-bug
+// compatibility with devices which compute the frame check sequence in this
+// way. UnmarshalFCS should be used in all other cases.
+func (f *Frame) UnmarshalFCSUntagged(b []byte) error {
+	// Must contain enough data for FCS, to avoid panics
+	if len(b) < 4 {
+		return io.ErrUnexpectedEOF
+	}
+
+	// Locate the end of the VLAN tags so they may be skipped.
+	body := b[0 : len(b)-4]
+	_, n, err := parseHeader(body, nil, headerOptions{})
+	if err != nil {
+		return err
+	}
+
 	want := binary.BigEndian.Uint32(b[len(b)-4:])
-	got := crc32.ChecksumIEEE(b[0 : len(b)-4])
-	if want != got {
+	if want != untaggedChecksum(body, (n-14)/4) {
+		return ErrInvalidFCS
+	}
+
+	return f.UnmarshalBinary(body)
+}
+
+// untaggedChecksum computes the IEEE CRC32 checksum of the binary form of a
+// Frame in b, skipping its first nTags VLAN tags.
+func untaggedChecksum(b []byte, nTags int) uint32 {
+	crc := crc32.Update(0, crc32.IEEETable, b[0:12])
+	return crc32.Update(crc, crc32.IEEETable, b[12+(4*nTags):])
+}
+
+// UnmarshalFCSN unmarshals the first Frame from a byte slice containing one
+// or more back-to-back Frames, each followed by a 4-byte IEEE CRC32 frame
+// check sequence, and returns the number of bytes consumed, including the
+// frame check sequence. The next Frame, if any, begins at b[n:].
+//
+// Because a Frame does not encode its own length, the end of the Frame is
+// located by searching for the first position at which a valid frame check
+// sequence follows at least MinFrameSize-4 bytes of data. Frames must
+// therefore be padded to the minimum size, as they are by MarshalFCS.
+//
+// If b is shorter than MinFrameSize, io.ErrUnexpectedEOF is returned. If no
+// valid frame check sequence is found, ErrInvalidFCS is returned.
+func (f *Frame) UnmarshalFCSN(b []byte) (int, error) {
+	const minBody = MinFrameSize - 4
+	if len(b) < MinFrameSize {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	// Compute the checksum incrementally, one byte at a time, checking it
+	// against the 4 bytes which follow at each position.
+	crc := crc32.Update(0, crc32.IEEETable, b[:minBody])
+	for i := minBody; i+4 <= len(b); i++ {
+		if crc == binary.BigEndian.Uint32(b[i:i+4]) {
+			if err := f.UnmarshalBinary(b[:i]); err != nil {
+				return 0, err
+			}
+
+			return i + 4, nil
+		}
+
+		crc = crc32.Update(crc, crc32.IEEETable, b[i:i+1])
+	}
+
+	return 0, ErrInvalidFCS
+}
+
+// UnmarshalFCSHash computes the frame check sequence of a Frame using h,
+// verifies it against the checksum present in the byte slice, and finally,
+// unmarshals a byte slice into a Frame.
+//
+// h is reset before use. UnmarshalFCSHash enables the use of alternative or
+// hardware-accelerated CRC32 implementations; UnmarshalFCS should be used
+// for the standard IEEE CRC32 frame check sequence.
+func (f *Frame) UnmarshalFCSHash(b []byte, h hash.Hash32) error {
+	// Must contain enough data for FCS, to avoid panics
+	if len(b) < 4 {
+		return io.ErrUnexpectedEOF
+	}
+
+	h.Reset()
+	_, _ = h.Write(b[0 : len(b)-4])
+
+	want := binary.BigEndian.Uint32(b[len(b)-4:])
+	if want != h.Sum32() {
 		return ErrInvalidFCS
 	}
 
 	return f.UnmarshalBinary(b[0 : len(b)-4])
 }
 
-func (f *Frame) length() int {
+// Length returns the length of a Frame's binary form, as produced by
+// MarshalBinary: its header, its payload padded to the minimum size, and its
+// Trailer, if any. The length of a frame check sequence is not included.
+func (f *Frame) Length() int {
 	pl := len(f.Payload)
-	if pl < minPayload {
-		pl = minPayload
+	if m := f.minPayload(); pl < m {
+		pl = m
+	}
+
+	return f.HeaderOverhead() + pl + len(f.Trailer)
+}
+
+// minPayload returns the size to which a Frame's payload is padded when it
+// is marshaled: MinPayload, or larger if required to reach f.MinSize, or 0
+// if f.NoPad is set.
+func (f *Frame) minPayload() int {
+	if f.NoPad {
+		return 0
+	}
+	if m := f.MinSize - f.HeaderOverhead(); m > MinPayload {
+		return m
+	}
+
+	return MinPayload
+}
+
+// DeclaredSize returns the size of a Frame as declared by its fields: its
+// header, its payload, and its Trailer, without any padding. DeclaredSize is useful for
+// accounting, and for protocols which do not pad Frames to the minimum size.
+//
+// DeclaredSize differs from Length only when the payload is shorter than
+// the minimum size, in which case Length reports the larger, padded size the
+// Frame occupies on the wire. Neither includes a frame check sequence.
+func (f *Frame) DeclaredSize() int {
+	return f.HeaderOverhead() + len(f.Payload) + len(f.Trailer)
+}
+
+// EqualBytes reports whether the binary form of a Frame is identical to b.
+// EqualBytes is useful for asserting that a Frame matches known bytes.
+//
+// A Frame is marshaled to perform the comparison, so a Frame with a payload
+// shorter than the minimum size is only equal to b if b contains the padded
+// payload, and its PadByte must match the padding in b. A frame check
+// sequence is not expected in b.
+//
+// If the Frame cannot be marshaled, EqualBytes returns the same errors as
+// MarshalBinary.
+func (f *Frame) EqualBytes(b []byte) (bool, error) {
+	fb, err := f.MarshalBinary()
+	if err != nil {
+		return false, err
 	}
 
-	return 6 + 6 + (4 * len(f.VLAN)) + 2 + pl
+	return bytes.Equal(fb, b), nil
+}
+
+// Equal reports whether f and o have identical hardware addresses, VLAN
+// tags, EtherTypes, payloads, and trailers. VLAN tags are compared by value.
+// PadByte, MinSize, NoPad, Raw, Timestamp, TagsTruncated, and Meta are not
+// compared.
+func (f *Frame) Equal(o *Frame) bool {
+	return f.equalHeader(o) && bytes.Equal(f.Payload, o.Payload) &&
+		bytes.Equal(f.Trailer, o.Trailer)
+}
+
+// EqualIgnorePadding is like Equal, but permits the payloads of f and o to
+// differ in length if the longer payload consists of the shorter payload
+// followed only by zero bytes, such as the padding added when a Frame with
+// a short payload is marshaled. Trailing bytes with any other value, such as
+// a non-zero PadByte, are not ignored. The trailers of f and o must be
+// identical.
+func (f *Frame) EqualIgnorePadding(o *Frame) bool {
+	if !f.equalHeader(o) || !bytes.Equal(f.Trailer, o.Trailer) {
+		return false
+	}
+
+	short, long := f.Payload, o.Payload
+	if len(short) > len(long) {
+		short, long = long, short
+	}
+
+	if !bytes.Equal(short, long[:len(short)]) {
+		return false
+	}
+
+	for _, b := range long[len(short):] {
+		if b != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// equalHeader reports whether f and o have identical hardware addresses,
+// VLAN tags, and EtherTypes.
+func (f *Frame) equalHeader(o *Frame) bool {
+	if !bytes.Equal(f.Destination, o.Destination) || !bytes.Equal(f.Source, o.Source) {
+		return false
+	}
+	if f.EtherType != o.EtherType || len(f.VLAN) != len(o.VLAN) {
+		return false
+	}
+
+	for i := range f.VLAN {
+		if *f.VLAN[i] != *o.VLAN[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsRunt reports whether a Frame would be considered a runt, and dropped by
+// a switch, if it were transmitted without padding: that is, if the size of
+// its header, payload, and 4-byte frame check sequence is less than the
+// minimum frame size of 64 bytes.
+//
+// Because marshaling a Frame pads its payload, IsRunt is computed from the
+// declared length of the payload. VLAN tags count towards the minimum frame
+// size, so each tag reduces the payload needed to avoid being a runt by 4
+// bytes.
+func (f *Frame) IsRunt() bool {
+	return f.HeaderOverhead()+len(f.Payload)+4 < MinFrameSize
+}
+
+// HeaderOverhead returns the length of a Frame's header: 12 bytes for the
+// hardware addresses, 4 bytes for each VLAN tag, and 2 bytes for the
+// EtherType. Subtracting HeaderOverhead from a Frame's Length produces
+// the length of its padded payload and any Trailer, which is useful for MTU
+// calculations.
+func (f *Frame) HeaderOverhead() int {
+	return 6 + 6 + (4 * len(f.VLAN)) + 2
+}
+
+// Protocol returns a short, human readable name for the upper layer protocol
+// encapsulated in a Frame, such as "IPv4" or "ARP". Because a Frame's
+// EtherType is the one which follows any VLAN tags, Protocol names the
+// innermost protocol of a tagged Frame.
+//
+// If the EtherType is not known to this package, its hexadecimal form,
+// such as "0x88b7", is returned.
+func (f *Frame) Protocol() string {
+	s := f.EtherType.String()
+	if strings.HasPrefix(s, "EtherType(") {
+		return fmt.Sprintf("0x%04x", uint16(f.EtherType))
+	}
+
+	return strings.TrimPrefix(s, "EtherType")
+}
+
+// Summary returns a one-line summary of a Frame in the style of tcpdump's
+// link-level output, for use by command line tools. For example:
+//
+//	00:16:3e:44:55:66 > ff:ff:ff:ff:ff:ff, ethertype ARP (0x0806), length 60
+//
+// For each VLAN tag present, the EtherType of the tag is printed, followed
+// by the tag's ID, priority, and drop eligibility:
+//
+//	00:16:3e:44:55:66 > 00:16:3e:11:22:33, ethertype 802.1Q (0x8100), length 64: vlan 100, p 5, ethertype IPv6 (0x86dd)
+//
+// The length is that of the Frame's binary form, as returned by Length.
+func (f *Frame) Summary() string {
+	var b strings.Builder
+
+	et := f.EtherType
+	if len(f.VLAN) > 0 {
+		et = f.VLAN[0].tpid()
+	}
+
+	fmt.Fprintf(&b, "%s > %s, %s, length %d",
+		f.Source, f.Destination, etherTypeSummary(et), f.Length())
+
+	for i, v := range f.VLAN {
+		if i == 0 {
+			b.WriteString(": ")
+		} else {
+			b.WriteString(", ")
+		}
+
+		fmt.Fprintf(&b, "vlan %d, p %d", v.ID, v.Priority)
+		if v.DropEligible {
+			b.WriteString(", DEI")
+		}
+
+		et := f.EtherType
+		if i < len(f.VLAN)-1 {
+			et = f.VLAN[i+1].tpid()
+		}
+
+		b.WriteString(", ")
+		b.WriteString(etherTypeSummary(et))
+	}
+
+	return b.String()
+}
+
+// etherTypeSummary returns the name and hexadecimal value of an EtherType,
+// as printed by tcpdump.
+func etherTypeSummary(e EtherType) string {
+	name := strings.TrimPrefix(e.String(), "EtherType")
+	switch {
+	case e == EtherTypeVLAN:
+		name = "802.1Q"
+	case e == EtherTypeServiceVLAN:
+		name = "802.1Q-QinQ"
+	case e == EtherTypeQinQ:
+		name = "802.1Q-9100"
+	case e == EtherTypeQinQAlt:
+		name = "802.1Q-9200"
+	case strings.HasPrefix(name, "("):
+		name = "Unknown"
+	}
+
+	return fmt.Sprintf("ethertype %s (0x%04x)", name, uint16(e))
+}
+
+// Clone returns a deep copy of a Frame: its hardware addresses, VLAN tags,
+// and payload are copied, so that the copy may be modified without
+// affecting f. Nil slices remain nil in the copy. The Meta map is copied, but
+// its values are not.
+func (f *Frame) Clone() *Frame {
+	c := *f
+	c.Destination = cloneBytes(f.Destination)
+	c.Source = cloneBytes(f.Source)
+	c.Payload = cloneBytes(f.Payload)
+	c.Trailer = cloneBytes(f.Trailer)
+	c.Raw = cloneBytes(f.Raw)
+
+	if f.Meta != nil {
+		c.Meta = make(map[string]interface{}, len(f.Meta))
+		for k, v := range f.Meta {
+			c.Meta[k] = v
+		}
+	}
+
+	if f.VLAN != nil {
+		c.VLAN = make([]*VLAN, len(f.VLAN))
+		for i, v := range f.VLAN {
+			c.VLAN[i] = v.Clone()
+		}
+	}
+
+	return &c
+}
+
+// Reverse returns a deep copy of a Frame, as returned by Clone, with its
+// Source and Destination hardware addresses swapped. Reverse is useful for
+// crafting a reply to a received Frame, such as in an echo server.
+func (f *Frame) Reverse() *Frame {
+	c := f.Clone()
+	c.Destination, c.Source = c.Source, c.Destination
+	return c
+}
+
+// cloneBytes returns a copy of b, or nil if b is nil.
+func cloneBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+
+	return append(make([]byte, 0, len(b)), b...)
+}
+
+// FitsMTU reports whether a Frame fits within a link with the specified
+// payload MTU, such as 1500 for standard Ethernet, without being fragmented
+// or dropped.
+//
+// The MTU is interpreted as it is by the Linux kernel when a Frame is sent
+// on a packet socket: it excludes the hardware addresses, the EtherType, and
+// a single VLAN tag, so a Frame with a 1500 byte payload and one VLAN tag
+// fits an MTU of 1500. Each further VLAN tag, and any Trailer, count against
+// the MTU. FitsMTU assumes that no frame check sequence is sent; see
+// MarshalForInterface for Frames which are.
+func (f *Frame) FitsMTU(mtu int) bool {
+	return f.mtuSize(false) <= mtu
+}
+
+// MarshalForInterface allocates a byte slice and marshals a Frame into binary
+// form, but first verifies that the Frame fits within the MTU of the network
+// interface which will send it, such as the value of net.Interface.MTU. If
+// fcs is true, the Frame is marshaled with a frame check sequence, as by
+// MarshalFCS, and the 4 bytes of the frame check sequence count against the
+// MTU. Otherwise, the Frame is marshaled as by MarshalBinary.
+//
+// The MTU is otherwise interpreted as it is by FitsMTU: the Frame's payload,
+// each VLAN tag after the first, and any Trailer count against it, but its
+// hardware addresses, EtherType, and first VLAN tag do not. An interface with
+// an MTU of 1500 therefore sends Frames with a 1500 byte payload whether or
+// not they carry a single VLAN tag.
+//
+// If the Frame does not fit, an error wrapping ErrFrameTooLarge is returned
+// which reports the MTU and the number of bytes by which it is exceeded,
+// rather than leaving the operating system to reject the Frame later.
+// MarshalForInterface otherwise returns the same errors as MarshalBinary.
+func (f *Frame) MarshalForInterface(mtu int, fcs bool) ([]byte, error) {
+	if n := f.mtuSize(fcs); n > mtu {
+		return nil, fmt.Errorf("%w: %d bytes exceeds MTU %d by %d bytes",
+			ErrFrameTooLarge, n, mtu, n-mtu)
+	}
+
+	if fcs {
+		return f.MarshalFCS()
+	}
+
+	return f.MarshalBinary()
+}
+
+// PadTo appends zero bytes to a Frame's payload, so that the length of its
+// binary form, as returned by Length, is exactly total bytes, which is
+// useful for generating Frames of a precise size for hardware tests. The
+// header, including any VLAN tags, and any Trailer count towards total; a
+// frame check sequence does not. The payload is copied, so any slice which
+// it previously referenced is not modified.
+//
+// If the Frame's Length already exceeds total, including when total is below
+// the Frame's minimum size, an error wrapping ErrFrameTooLarge is returned,
+// and the Frame is not modified.
+func (f *Frame) PadTo(total int) error {
+	if l := f.Length(); l > total {
+		return fmt.Errorf("%w: %d bytes exceeds requested length %d by %d bytes",
+			ErrFrameTooLarge, l, total, l-total)
+	}
+
+	p := make([]byte, total-f.HeaderOverhead()-len(f.Trailer))
+	copy(p, f.Payload)
+	f.Payload = p
+	return nil
+}
+
+// mtuSize returns the number of bytes of a Frame which count against a link
+// MTU: its payload, each VLAN tag after the first, its Trailer, and its frame
+// check sequence if fcs is true.
+func (f *Frame) mtuSize(fcs bool) int {
+	n := len(f.Payload) + len(f.Trailer)
+	if len(f.VLAN) > 1 {
+		n += 4 * (len(f.VLAN) - 1)
+	}
+	if fcs {
+		n += 4
+	}
+
+	return n
+}
+
+// FlowHash computes a stable 32-bit hash of a Frame's layer 2 flow, which is
+// useful for distributing Frames between buckets, as a switch does when
+// balancing traffic across the links of an aggregate.
+//
+// The hash is the 32-bit FNV-1a hash of the following bytes, in order: the
+// destination hardware address, the source hardware address, the 12-bit ID
+// of the outermost VLAN tag (or 0, if none is present) as a 2-byte big-endian
+// value, and the EtherType as a 2-byte big-endian value. The payload and
+// any inner VLAN tags are not included.
+func (f *Frame) FlowHash() uint32 {
+	var id uint16
+	if len(f.VLAN) > 0 {
+		id = f.VLAN[0].ID
+	}
+
+	var b [4]byte
+	binary.BigEndian.PutUint16(b[0:2], id)
+	binary.BigEndian.PutUint16(b[2:4], uint16(f.EtherType))
+
+	h := fnv.New32a()
+	_, _ = h.Write(f.Destination)
+	_, _ = h.Write(f.Source)
+	_, _ = h.Write(b[:])
+	return h.Sum32()
+}
+
+// PayloadEntropy computes the Shannon entropy of a Frame's payload bytes, in
+// bits per byte, from 0 for a payload consisting of a single repeated byte,
+// to 8 for a payload in which every byte value occurs equally often. High
+// entropy is a quick signal that a payload is encrypted or compressed,
+// rather than plaintext, which is useful for anomaly detection.
+//
+// PayloadEntropy returns 0 for an empty payload. Padding is not included.
+func (f *Frame) PayloadEntropy() float64 {
+	if len(f.Payload) == 0 {
+		return 0
+	}
+
+	var counts [256]int
+	for _, b := range f.Payload {
+		counts[b]++
+	}
+
+	var (
+		e float64
+		n = float64(len(f.Payload))
+	)
+	for _, c := range counts {
+		if c == 0 {
+			continue
+		}
+
+		p := float64(c) / n
+		e -= p * math.Log2(p)
+	}
+
+	return e
+}
+
+// PayloadCRC32 computes the IEEE CRC32 checksum of a Frame's payload, which
+// is useful for application-level integrity checks of the payload alone.
+// PayloadCRC32 is distinct from the frame check sequence computed by FCS,
+// which covers a Frame's entire binary form. Padding is not included, and
+// PayloadCRC32 returns 0 for an empty payload.
+func (f *Frame) PayloadCRC32() uint32 {
+	return crc32.ChecksumIEEE(f.Payload)
 }
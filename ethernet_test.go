@@ -2,10 +2,18 @@ package ethernet
 
 import (
 	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"hash/crc32"
 	"io"
+	"math"
 	"net"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestFrameMarshalBinary(t *testing.T) {
@@ -254,6 +262,109 @@ func TestFrameUnmarshalBinary(t *testing.T) {
 	}
 }
 
+func TestFrameUnmarshalBinaryReuseFrame(t *testing.T) {
+	// Q-in-Q, then 802.1Q, then untagged.
+	qinq, dot1q, untagged := TestVectors[3], TestVectors[2], TestVectors[0]
+
+	f := new(Frame)
+	for _, v := range []TestVector{qinq, dot1q, untagged, qinq} {
+		if err := f.UnmarshalBinary(v.Bytes); err != nil {
+			t.Fatalf("failed to unmarshal Frame: %v", err)
+		}
+
+		if !f.Equal(v.Frame) {
+			t.Fatalf("unexpected Frame:\n- want: %v\n- got: %v", v.Frame, f)
+		}
+	}
+
+	// VLAN structs from a previous Frame must not be modified.
+	vlans := f.VLAN
+	if err := f.UnmarshalBinary(dot1q.Bytes); err != nil {
+		t.Fatalf("failed to unmarshal Frame: %v", err)
+	}
+	if want, got := qinq.Frame.VLAN, vlans; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected previous VLANs:\n- want: %v\n- got: %v", want, got)
+	}
+}
+
+func TestFrameUnmarshalBinaryNTagsReuseFrame(t *testing.T) {
+	v := TestVectors[2]
+
+	// Fields set by a Decoder must not persist when a Frame is reused.
+	f := &Frame{
+		Trailer:       []byte{0xaa, 0xbb},
+		Raw:           []byte{0xcc, 0xdd},
+		TagsTruncated: true,
+	}
+	if err := f.UnmarshalBinaryNTags(v.Bytes, len(v.Frame.VLAN)); err != nil {
+		t.Fatalf("failed to unmarshal Frame: %v", err)
+	}
+
+	if f.Trailer != nil {
+		t.Fatalf("unexpected trailer: %v", f.Trailer)
+	}
+	if f.Raw != nil {
+		t.Fatalf("unexpected raw bytes: %v", f.Raw)
+	}
+	if f.TagsTruncated {
+		t.Fatal("unexpected truncated tags")
+	}
+
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+	if want, got := v.Bytes, b; !bytes.Equal(want, got) {
+		t.Fatalf("unexpected Frame bytes:\n- want: %v\n- got: %v", want, got)
+	}
+}
+
+func TestFrameUnmarshalBinaryKeepRaw(t *testing.T) {
+	// A short payload which is padded differently than a Frame's PadByte
+	// would pad it cannot be reproduced by marshaling.
+	b := append([]byte{
+		0, 1, 0, 1, 0, 1,
+		1, 0, 1, 0, 1, 0,
+		0x08, 0x00,
+		0xde, 0xad,
+	}, bytes.Repeat([]byte{0xa5}, 44)...)
+
+	f := new(Frame)
+	if err := f.UnmarshalBinaryKeepRaw(b); err != nil {
+		t.Fatalf("failed to unmarshal Frame: %v", err)
+	}
+
+	if want, got := b, f.Raw; !bytes.Equal(want, got) {
+		t.Fatalf("unexpected raw bytes:\n- want: %v\n- got: %v", want, got)
+	}
+
+	// Raw must not alias the input.
+	b[0] = 0xff
+	if want, got := byte(0), f.Raw[0]; want != got {
+		t.Fatalf("raw bytes alias input: %#02x != %#02x", want, got)
+	}
+
+	// Raw is ignored by marshaling, and cleared by other unmarshal methods.
+	mb, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+	if want, got := 60, len(mb); want != got {
+		t.Fatalf("unexpected Frame length: %v != %v", want, got)
+	}
+
+	if err := f.UnmarshalBinary(mb); err != nil {
+		t.Fatalf("failed to unmarshal Frame: %v", err)
+	}
+	if f.Raw != nil {
+		t.Fatalf("unexpected raw bytes: %v", f.Raw)
+	}
+
+	if err := f.UnmarshalBinaryKeepRaw(b[:13]); err != io.ErrUnexpectedEOF {
+		t.Fatalf("unexpected error: %v != %v", io.ErrUnexpectedEOF, err)
+	}
+}
+
 // Benchmarks for Frame.MarshalBinary with varying VLAn tags and payloads
 
 func BenchmarkFrameMarshalBinary(b *testing.B) {
@@ -548,3 +659,3176 @@ func benchmarkFrameUnmarshalFCS(b *testing.B, f *Frame) {
 		}
 	}
 }
+
+func TestUnmarshalPartial(t *testing.T) {
+	var tests = []struct {
+		desc string
+		b    []byte
+		f    *Frame
+		n    int
+		err  error
+	}{
+		{
+			desc: "nil buffer",
+			f:    &Frame{},
+			err:  io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "destination only",
+			b:    []byte{0, 1, 0, 1, 0, 1, 1, 0},
+			f: &Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+			},
+			n:   6,
+			err: io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "destination and source",
+			b: []byte{
+				0, 1, 0, 1, 0, 1,
+				1, 0, 1, 0, 1, 0,
+				0x08,
+			},
+			f: &Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+			},
+			n:   12,
+			err: io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "1 VLAN, truncated EtherType",
+			b: []byte{
+				0, 1, 0, 1, 0, 1,
+				1, 0, 1, 0, 1, 0,
+				0x81, 0x00,
+				0x20, 0x65,
+				0x86,
+			},
+			f: &Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				VLAN: []*VLAN{{
+					Priority: 1,
+					ID:       101,
+				}},
+			},
+			n:   16,
+			err: io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "complete frame",
+			b: []byte{
+				0, 1, 0, 1, 0, 1,
+				1, 0, 1, 0, 1, 0,
+				0x08, 0x00,
+				0xde, 0xad,
+			},
+			f: &Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				EtherType:   EtherTypeIPv4,
+				Payload:     []byte{0xde, 0xad},
+			},
+			n: 16,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f, n, err := UnmarshalPartial(tt.b)
+			if want, got := tt.err, err; want != got {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.n, n; want != got {
+				t.Fatalf("[%02d] test %q, unexpected bytes consumed: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.f, f; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected Frame:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameFCSHash(t *testing.T) {
+	f := &Frame{
+		Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+		Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+		EtherType:   EtherTypeIPv4,
+		Payload:     bytes.Repeat([]byte{0}, 50),
+	}
+
+	// Using the IEEE polynomial must produce the same output as MarshalFCS.
+	want, err := f.MarshalFCS()
+	if err != nil {
+		t.Fatalf("failed to marshal FCS: %v", err)
+	}
+
+	got, err := f.MarshalFCSHash(crc32.NewIEEE())
+	if err != nil {
+		t.Fatalf("failed to marshal FCS with hash: %v", err)
+	}
+
+	if !bytes.Equal(want, got) {
+		t.Fatalf("unexpected Frame bytes:\n- want: %v\n- got: %v", want, got)
+	}
+
+	// A Castagnoli checksum must round-trip, but only with the same hash.
+	castagnoli := crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	b, err := f.MarshalFCSHash(castagnoli)
+	if err != nil {
+		t.Fatalf("failed to marshal FCS with hash: %v", err)
+	}
+
+	if err := new(Frame).UnmarshalFCS(b); err != ErrInvalidFCS {
+		t.Fatalf("unexpected error for IEEE FCS: %v != %v", ErrInvalidFCS, err)
+	}
+
+	f2 := new(Frame)
+	if err := f2.UnmarshalFCSHash(b, castagnoli); err != nil {
+		t.Fatalf("failed to unmarshal FCS with hash: %v", err)
+	}
+
+	if want, got := f, f2; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected Frame:\n- want: %v\n- got: %v", want, got)
+	}
+}
+
+func TestFrameUnmarshalBinaryNTags(t *testing.T) {
+	var tests = []struct {
+		desc  string
+		b     []byte
+		nTags int
+		f     *Frame
+		err   error
+	}{
+		{
+			desc:  "negative tag count",
+			b:     bytes.Repeat([]byte{0}, 14),
+			nTags: -1,
+			err:   ErrInvalidVLAN,
+		},
+		{
+			desc: "too short for tags",
+			b: []byte{
+				0, 0, 0, 0, 0, 0,
+				0, 0, 0, 0, 0, 0,
+				0x88, 0xa8,
+				0x00, 0x64,
+				0x08, 0x00,
+			},
+			nTags: 2,
+			err:   io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "0 tags, VLAN EtherType not sniffed",
+			b: []byte{
+				0, 1, 0, 1, 0, 1,
+				1, 0, 1, 0, 1, 0,
+				0x81, 0x00,
+				0x00, 0x64,
+			},
+			f: &Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				EtherType:   EtherTypeVLAN,
+				Payload:     []byte{0x00, 0x64},
+			},
+		},
+		{
+			desc: "2 tags, non-standard outer TPID",
+			b: []byte{
+				0, 1, 0, 1, 0, 1,
+				1, 0, 1, 0, 1, 0,
+				0x88, 0xa8,
+				0x00, 0x64,
+				0x81, 0x00,
+				0x20, 0x65,
+				0x08, 0x00,
+				0xde, 0xad,
+			},
+			nTags: 2,
+			f: &Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				VLAN: []*VLAN{
+					{
+						ID:   100,
+						TPID: EtherTypeServiceVLAN,
+					},
+					{
+						Priority: 1,
+						ID:       101,
+					},
+				},
+				EtherType: EtherTypeIPv4,
+				Payload:   []byte{0xde, 0xad},
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f := new(Frame)
+			if err := f.UnmarshalBinaryNTags(tt.b, tt.nTags); err != nil {
+				if want, got := tt.err, err; want != got {
+					t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+						i, tt.desc, want, got)
+				}
+
+				return
+			}
+
+			if want, got := tt.f, f; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected Frame:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameBinaryInterfaces(t *testing.T) {
+	want := &Frame{
+		Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+		Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+		VLAN: []*VLAN{{
+			Priority: 1,
+			ID:       101,
+		}},
+		EtherType: EtherTypeIPv6,
+		Payload:   bytes.Repeat([]byte{0}, 50),
+	}
+
+	var (
+		m encoding.BinaryMarshaler   = want
+		u encoding.BinaryUnmarshaler = new(Frame)
+	)
+
+	b, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+	if err := u.UnmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal Frame: %v", err)
+	}
+
+	if got := u.(*Frame); !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected Frame:\n- want: %v\n- got: %v", want, got)
+	}
+
+	// gob uses the binary encoding interfaces when they are implemented.
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(want); err != nil {
+		t.Fatalf("failed to gob encode Frame: %v", err)
+	}
+
+	got := new(Frame)
+	if err := gob.NewDecoder(&buf).Decode(got); err != nil {
+		t.Fatalf("failed to gob decode Frame: %v", err)
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected Frame:\n- want: %v\n- got: %v", want, got)
+	}
+}
+
+func TestFrameMarshalHeader(t *testing.T) {
+	var tests = []struct {
+		desc string
+		f    *Frame
+		b    []byte
+		err  error
+	}{
+		{
+			desc: "VLAN ID too large",
+			f: &Frame{
+				VLAN: []*VLAN{{
+					ID: VLANMax,
+				}},
+			},
+			err: ErrInvalidVLAN,
+		},
+		{
+			desc: "IPv4, no VLANs, payload ignored",
+			f: &Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				EtherType:   EtherTypeIPv4,
+				Payload:     []byte{0xde, 0xad},
+			},
+			b: []byte{
+				0, 1, 0, 1, 0, 1,
+				1, 0, 1, 0, 1, 0,
+				0x08, 0x00,
+			},
+		},
+		{
+			desc: "IPv6, 1 VLAN: PRI 1, ID 101",
+			f: &Frame{
+				Destination: net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				Source:      net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				VLAN: []*VLAN{{
+					Priority: 1,
+					ID:       101,
+				}},
+				EtherType: EtherTypeIPv6,
+			},
+			b: []byte{
+				1, 0, 1, 0, 1, 0,
+				0, 1, 0, 1, 0, 1,
+				0x81, 0x00,
+				0x20, 0x65,
+				0x86, 0xDD,
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			b, err := tt.f.MarshalHeader()
+			if err != nil {
+				if want, got := tt.err, err; want != got {
+					t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+						i, tt.desc, want, got)
+				}
+
+				return
+			}
+
+			if want, got := tt.b, b; !bytes.Equal(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected header bytes:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameMarshalBinaryPadByte(t *testing.T) {
+	f := &Frame{
+		Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+		Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+		EtherType:   EtherTypeIPv4,
+		Payload:     []byte{0xde, 0xad},
+		PadByte:     0xa5,
+	}
+
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+
+	want := append([]byte{
+		0, 1, 0, 1, 0, 1,
+		1, 0, 1, 0, 1, 0,
+		0x08, 0x00,
+		0xde, 0xad,
+	}, bytes.Repeat([]byte{0xa5}, 44)...)
+
+	if got := b; !bytes.Equal(want, got) {
+		t.Fatalf("unexpected Frame bytes:\n- want: %v\n- got: %v", want, got)
+	}
+
+	// The frame check sequence must not be overwritten by padding.
+	b, err = f.MarshalFCS()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame with FCS: %v", err)
+	}
+
+	if err := new(Frame).UnmarshalFCS(b); err != nil {
+		t.Fatalf("failed to unmarshal Frame with FCS: %v", err)
+	}
+}
+
+func TestFrameMarshalBinaryMinSize(t *testing.T) {
+	var tests = []struct {
+		desc    string
+		minSize int
+		noPad   bool
+		vlans   int
+		payload int
+		n       int
+	}{
+		{
+			desc:    "default",
+			payload: 2,
+			n:       60,
+		},
+		{
+			desc:    "smaller than minimum payload",
+			minSize: 20,
+			payload: 2,
+			n:       60,
+		},
+		{
+			desc:    "60 bytes",
+			minSize: 60,
+			payload: 2,
+			n:       60,
+		},
+		{
+			desc:    "64 bytes",
+			minSize: 64,
+			payload: 2,
+			n:       64,
+		},
+		{
+			desc:    "64 bytes, VLAN",
+			minSize: 64,
+			vlans:   1,
+			payload: 2,
+			n:       64,
+		},
+		{
+			desc:    "64 bytes, 2 VLANs",
+			minSize: 64,
+			vlans:   2,
+			payload: 2,
+			n:       68,
+		},
+		{
+			desc:    "64 bytes, large payload",
+			minSize: 64,
+			payload: 100,
+			n:       114,
+		},
+		{
+			desc:    "NoPad",
+			noPad:   true,
+			payload: 2,
+			n:       16,
+		},
+		{
+			desc:    "NoPad overrides MinSize",
+			minSize: 64,
+			noPad:   true,
+			vlans:   1,
+			payload: 2,
+			n:       20,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f := &Frame{
+				Destination: Broadcast,
+				Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+				EtherType:   EtherTypeIPv4,
+				Payload:     bytes.Repeat([]byte{0xaa}, tt.payload),
+				PadByte:     0xa5,
+				MinSize:     tt.minSize,
+				NoPad:       tt.noPad,
+			}
+			for j := 0; j < tt.vlans; j++ {
+				f.VLAN = append(f.VLAN, &VLAN{ID: 10})
+			}
+
+			b, err := f.MarshalBinary()
+			if err != nil {
+				t.Fatalf("[%02d] test %q, failed to marshal Frame: %v",
+					i, tt.desc, err)
+			}
+
+			if want, got := tt.n, len(b); want != got {
+				t.Fatalf("[%02d] test %q, unexpected Frame length: %v != %v",
+					i, tt.desc, want, got)
+			}
+			if want, got := tt.n, f.Length(); want != got {
+				t.Fatalf("[%02d] test %q, unexpected Length: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			pad := b[f.HeaderOverhead()+tt.payload:]
+			if want, got := bytes.Repeat([]byte{0xa5}, len(pad)), pad; !bytes.Equal(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected padding: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameMarshalBinaryTrailer(t *testing.T) {
+	f := &Frame{
+		Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+		Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+		EtherType:   EtherTypeIPv4,
+		Payload:     []byte{0xde, 0xad},
+		PadByte:     0xa5,
+		Trailer:     []byte{0xca, 0xfe, 0xba, 0xbe},
+	}
+
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+
+	// The trailer follows the padded payload.
+	want := append([]byte{
+		0, 1, 0, 1, 0, 1,
+		1, 0, 1, 0, 1, 0,
+		0x08, 0x00,
+		0xde, 0xad,
+	}, bytes.Repeat([]byte{0xa5}, 44)...)
+	want = append(want, 0xca, 0xfe, 0xba, 0xbe)
+
+	if got := b; !bytes.Equal(want, got) {
+		t.Fatalf("unexpected Frame bytes:\n- want: %v\n- got: %v", want, got)
+	}
+	if want, got := len(want), f.Length(); want != got {
+		t.Fatalf("unexpected Length: %v != %v", want, got)
+	}
+
+	// The trailer precedes, and is covered by, the frame check sequence.
+	b, err = f.MarshalFCS()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame with FCS: %v", err)
+	}
+	if want, got := AppendFCS(want), b; !bytes.Equal(want, got) {
+		t.Fatalf("unexpected Frame bytes with FCS:\n- want: %v\n- got: %v", want, got)
+	}
+
+	// Frames which differ only in their trailers are not equal.
+	c := f.Clone()
+	c.Trailer[0] = 0xff
+	if f.Equal(c) || f.EqualIgnorePadding(c) {
+		t.Fatal("Frames with different trailers are equal")
+	}
+}
+
+func TestFrameMarshalFCSTrailerExcluded(t *testing.T) {
+	var tests = []struct {
+		desc     string
+		excluded bool
+	}{
+		{
+			desc: "trailer covered",
+		},
+		{
+			desc:     "trailer excluded",
+			excluded: true,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f := &Frame{
+				Destination:            net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:                 net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				VLAN:                   []*VLAN{{ID: 10}},
+				EtherType:              EtherTypeIPv4,
+				Payload:                []byte{0xde, 0xad},
+				Trailer:                []byte{0xca, 0xfe, 0xba, 0xbe},
+				TrailerExcludedFromFCS: tt.excluded,
+			}
+
+			body, err := f.MarshalBinary()
+			if err != nil {
+				t.Fatalf("[%02d] test %q, failed to marshal Frame: %v",
+					i, tt.desc, err)
+			}
+
+			// The trailer always precedes the frame check sequence, but is
+			// only covered by it if not excluded.
+			covered := body
+			if tt.excluded {
+				covered = body[:len(body)-len(f.Trailer)]
+			}
+
+			var fcs [4]byte
+			binary.BigEndian.PutUint32(fcs[:], FCS(covered))
+			want := append(append([]byte(nil), body...), fcs[:]...)
+
+			fns := map[string]func() ([]byte, error){
+				"MarshalFCS": f.MarshalFCS,
+				"MarshalFCSHash": func() ([]byte, error) {
+					return f.MarshalFCSHash(crc32.NewIEEE())
+				},
+				"MarshalBatchFCS": func() ([]byte, error) {
+					b := make([]byte, len(want))
+					if _, err := MarshalBatchFCS(b, []*Frame{f}); err != nil {
+						return nil, err
+					}
+					return b, nil
+				},
+				"WriteFCS": func() ([]byte, error) {
+					var buf bytes.Buffer
+					if _, _, err := f.WriteFCS(&buf); err != nil {
+						return nil, err
+					}
+					return buf.Bytes(), nil
+				},
+			}
+
+			for name, fn := range fns {
+				got, err := fn()
+				if err != nil {
+					t.Fatalf("[%02d] test %q, %s: failed to marshal Frame: %v",
+						i, tt.desc, name, err)
+				}
+
+				if !bytes.Equal(want, got) {
+					t.Fatalf("[%02d] test %q, %s: unexpected Frame bytes:\n- want: %v\n- got: %v",
+						i, tt.desc, name, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestFrameUnmarshalBinaryZeroCopy(t *testing.T) {
+	b := []byte{
+		0, 1, 0, 1, 0, 1,
+		1, 0, 1, 0, 1, 0,
+		0x81, 0x00,
+		0x20, 0x65,
+		0x08, 0x00,
+		0xde, 0xad,
+	}
+
+	f := new(Frame)
+	if err := f.UnmarshalBinaryZeroCopy(b); err != nil {
+		t.Fatalf("failed to unmarshal Frame: %v", err)
+	}
+
+	want := &Frame{
+		Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+		Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+		VLAN: []*VLAN{{
+			Priority: 1,
+			ID:       101,
+		}},
+		EtherType: EtherTypeIPv4,
+		Payload:   []byte{0xde, 0xad},
+	}
+
+	if got := f; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected Frame:\n- want: %v\n- got: %v", want, got)
+	}
+
+	// The Frame aliases the input buffer.
+	b[0] = 0xff
+	b[len(b)-1] = 0xff
+	if f.Destination[0] != 0xff || f.Payload[1] != 0xff {
+		t.Fatal("Frame does not alias input buffer")
+	}
+
+	// Appending to an address must not overwrite the input buffer.
+	_ = append(f.Destination, 0xee)
+	if b[6] != 1 {
+		t.Fatal("append to Destination overwrote Source")
+	}
+
+	if err := f.UnmarshalBinaryZeroCopy(b[:13]); err != io.ErrUnexpectedEOF {
+		t.Fatalf("unexpected error: %v != %v", io.ErrUnexpectedEOF, err)
+	}
+}
+
+func BenchmarkFrameUnmarshalBinaryZeroCopy(b *testing.B) {
+	f := &Frame{
+		Destination: net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+		Source:      net.HardwareAddr{0xad, 0xbe, 0xef, 0xde, 0xad, 0xde},
+		Payload:     []byte{0, 1, 2, 3, 4},
+	}
+
+	fb, err := f.MarshalBinary()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := f.UnmarshalBinaryZeroCopy(fb); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestFrameIsRunt(t *testing.T) {
+	var tests = []struct {
+		desc string
+		f    *Frame
+		ok   bool
+	}{
+		{
+			desc: "empty payload",
+			f:    &Frame{},
+			ok:   true,
+		},
+		{
+			desc: "45 byte payload",
+			f: &Frame{
+				Payload: make([]byte, 45),
+			},
+			ok: true,
+		},
+		{
+			desc: "46 byte payload",
+			f: &Frame{
+				Payload: make([]byte, 46),
+			},
+		},
+		{
+			desc: "1 VLAN, 41 byte payload",
+			f: &Frame{
+				VLAN:    []*VLAN{{}},
+				Payload: make([]byte, 41),
+			},
+			ok: true,
+		},
+		{
+			desc: "1 VLAN, 42 byte payload",
+			f: &Frame{
+				VLAN:    []*VLAN{{}},
+				Payload: make([]byte, 42),
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if want, got := tt.ok, tt.f.IsRunt(); want != got {
+				t.Fatalf("[%02d] test %q, unexpected runt: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameEqualBytes(t *testing.T) {
+	f := &Frame{
+		Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+		Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+		EtherType:   EtherTypeIPv4,
+		Payload:     []byte{0xde, 0xad},
+	}
+
+	header := []byte{
+		0, 1, 0, 1, 0, 1,
+		1, 0, 1, 0, 1, 0,
+		0x08, 0x00,
+		0xde, 0xad,
+	}
+
+	var tests = []struct {
+		desc string
+		f    *Frame
+		b    []byte
+		ok   bool
+		err  error
+	}{
+		{
+			desc: "invalid VLAN",
+			f: &Frame{
+				VLAN: []*VLAN{{
+					ID: VLANMax,
+				}},
+			},
+			err: ErrInvalidVLAN,
+		},
+		{
+			desc: "unpadded",
+			f:    f,
+			b:    header,
+		},
+		{
+			desc: "padded",
+			f:    f,
+			b:    append(header, make([]byte, 44)...),
+			ok:   true,
+		},
+		{
+			desc: "different payload",
+			f:    f,
+			b:    append(header, bytes.Repeat([]byte{1}, 44)...),
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ok, err := tt.f.EqualBytes(tt.b)
+			if want, got := tt.err, err; want != got {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.ok, ok; want != got {
+				t.Fatalf("[%02d] test %q, unexpected equality: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameUnmarshalBinaryUndefinedRange(t *testing.T) {
+	b := append([]byte{
+		0, 1, 0, 1, 0, 1,
+		1, 0, 1, 0, 1, 0,
+		0x05, 0xdd,
+	}, make([]byte, 1510)...)
+
+	var tests = []struct {
+		desc   string
+		policy UndefinedRangePolicy
+		b      []byte
+		n      int
+		err    error
+	}{
+		{
+			desc:   "EtherType",
+			policy: UndefinedRangeEtherType,
+			b:      b,
+			n:      1510,
+		},
+		{
+			desc:   "length",
+			policy: UndefinedRangeLength,
+			b:      b,
+			n:      1501,
+		},
+		{
+			desc:   "length, short payload",
+			policy: UndefinedRangeLength,
+			b:      b[:1514],
+			err:    io.ErrUnexpectedEOF,
+		},
+		{
+			desc:   "error",
+			policy: UndefinedRangeError,
+			b:      b,
+			err:    ErrInvalidEtherType,
+		},
+	}
+
+	defer func() { UndefinedRange = UndefinedRangeEtherType }()
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			UndefinedRange = tt.policy
+
+			// Each method which unmarshals a Frame applies the policy.
+			fns := map[string]func(f *Frame, b []byte) error{
+				"UnmarshalBinary": (*Frame).UnmarshalBinary,
+				"UnmarshalBinaryNTags": func(f *Frame, b []byte) error {
+					return f.UnmarshalBinaryNTags(b, 0)
+				},
+			}
+
+			for name, fn := range fns {
+				f := new(Frame)
+				if err := fn(f, tt.b); err != nil {
+					if want, got := tt.err, err; want != got {
+						t.Fatalf("[%02d] test %q, %s: unexpected error: %v != %v",
+							i, tt.desc, name, want, got)
+					}
+
+					continue
+				}
+				if tt.err != nil {
+					t.Fatalf("[%02d] test %q, %s: expected error: %v",
+						i, tt.desc, name, tt.err)
+				}
+
+				if want, got := EtherType(1501), f.EtherType; want != got {
+					t.Fatalf("[%02d] test %q, %s: unexpected EtherType: %v != %v",
+						i, tt.desc, name, want, got)
+				}
+
+				if want, got := tt.n, len(f.Payload); want != got {
+					t.Fatalf("[%02d] test %q, %s: unexpected payload length: %v != %v",
+						i, tt.desc, name, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestFrameByteOrder(t *testing.T) {
+	f := &Frame{
+		VLAN: []*VLAN{{
+			Priority: PriorityNetworkControl,
+			ID:       0x123,
+		}},
+		EtherType: 0x0102,
+		Payload:   make([]byte, 46),
+	}
+
+	b, err := f.MarshalFCS()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+
+	// Each field must be present at a fixed offset, most significant
+	// byte first.
+	var tests = []struct {
+		desc   string
+		offset int
+		b      []byte
+	}{
+		{
+			desc:   "VLAN TPID",
+			offset: 12,
+			b:      []byte{0x81, 0x00},
+		},
+		{
+			desc:   "VLAN TCI",
+			offset: 14,
+			b:      []byte{0xe1, 0x23},
+		},
+		{
+			desc:   "EtherType",
+			offset: 16,
+			b:      []byte{0x01, 0x02},
+		},
+		{
+			desc:   "FCS",
+			offset: len(b) - 4,
+			b: func() []byte {
+				fcs := crc32.ChecksumIEEE(b[:len(b)-4])
+				return []byte{byte(fcs >> 24), byte(fcs >> 16), byte(fcs >> 8), byte(fcs)}
+			}(),
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if want, got := tt.b, b[tt.offset:tt.offset+len(tt.b)]; !bytes.Equal(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected bytes at offset %d:\n- want: %v\n- got: %v",
+					i, tt.desc, tt.offset, want, got)
+			}
+		})
+	}
+
+	// Unmarshaling must read the same fields in the same order.
+	f2 := new(Frame)
+	if err := f2.UnmarshalFCS(b); err != nil {
+		t.Fatalf("failed to unmarshal Frame: %v", err)
+	}
+
+	if want, got := EtherType(0x0102), f2.EtherType; want != got {
+		t.Fatalf("unexpected EtherType: %#04x != %#04x", want, got)
+	}
+
+	if want, got := uint16(0x123), f2.VLAN[0].ID; want != got {
+		t.Fatalf("unexpected VLAN ID: %#03x != %#03x", want, got)
+	}
+}
+
+func TestFrameUnmarshalBinaryAddrView(t *testing.T) {
+	b := []byte{
+		0, 1, 0, 1, 0, 1,
+		1, 0, 1, 0, 1, 0,
+		0x08, 0x00,
+		0xde, 0xad,
+	}
+
+	f := new(Frame)
+	if err := f.UnmarshalBinaryAddrView(b); err != nil {
+		t.Fatalf("failed to unmarshal Frame: %v", err)
+	}
+
+	want := &Frame{
+		Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+		Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+		EtherType:   EtherTypeIPv4,
+		Payload:     []byte{0xde, 0xad},
+	}
+
+	if got := f; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected Frame:\n- want: %v\n- got: %v", want, got)
+	}
+
+	// Addresses alias the input buffer, but the payload does not.
+	b[6] = 0xff
+	b[len(b)-1] = 0xff
+	if f.Source[0] != 0xff {
+		t.Fatal("Source does not alias input buffer")
+	}
+	if f.Payload[1] != 0xad {
+		t.Fatal("Payload aliases input buffer")
+	}
+
+	if err := f.UnmarshalBinaryAddrView(b[:13]); err != io.ErrUnexpectedEOF {
+		t.Fatalf("unexpected error: %v != %v", io.ErrUnexpectedEOF, err)
+	}
+}
+
+func BenchmarkFrameUnmarshalBinaryAddrView(b *testing.B) {
+	f := &Frame{
+		Destination: net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+		Source:      net.HardwareAddr{0xad, 0xbe, 0xef, 0xde, 0xad, 0xde},
+		Payload:     []byte{0, 1, 2, 3, 4},
+	}
+
+	fb, err := f.MarshalBinary()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := f.UnmarshalBinaryAddrView(fb); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestFrameMarshalBinaryTo(t *testing.T) {
+	f := &Frame{
+		Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+		Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+		EtherType:   EtherTypeIPv4,
+		Payload:     []byte{0xde, 0xad},
+	}
+
+	want, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+
+	// A buffer which is larger than needed may be reused.
+	b := bytes.Repeat([]byte{0xff}, 128)
+	n, err := f.MarshalBinaryTo(b)
+	if err != nil {
+		t.Fatalf("failed to marshal Frame into buffer: %v", err)
+	}
+
+	if want, got := f.Length(), n; want != got {
+		t.Fatalf("unexpected number of bytes written: %v != %v", want, got)
+	}
+
+	if got := b[:n]; !bytes.Equal(want, got) {
+		t.Fatalf("unexpected Frame bytes:\n- want: %v\n- got: %v", want, got)
+	}
+
+	// A buffer which is too small must return an error, not panic.
+	_, err = f.MarshalBinaryTo(make([]byte, 4))
+	if !errors.Is(err, ErrBufferTooSmall) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want, got := "buffer too small for frame: need 60 bytes, have 4", err.Error(); want != got {
+		t.Fatalf("unexpected error message: %q != %q", want, got)
+	}
+}
+
+func TestFrameMarshalBinaryToReusedBuffer(t *testing.T) {
+	// A Frame without addresses must not leave the previous contents of a
+	// reused buffer in its header, nor in its padding.
+	f := &Frame{
+		EtherType: EtherTypeIPv4,
+		Payload:   []byte{0xde, 0xad},
+	}
+
+	want, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+
+	b := bytes.Repeat([]byte{0xff}, f.Length())
+	if _, err := f.MarshalBinaryTo(b); err != nil {
+		t.Fatalf("failed to marshal Frame into buffer: %v", err)
+	}
+
+	if got := b; !bytes.Equal(want, got) {
+		t.Fatalf("unexpected Frame bytes:\n- want: %v\n- got: %v", want, got)
+	}
+}
+
+func TestFrameMarshalBinaryDeterministic(t *testing.T) {
+	var tests = []struct {
+		desc string
+		f    *Frame
+	}{
+		{
+			desc: "padded",
+			f: &Frame{
+				Destination: Broadcast,
+				Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+				EtherType:   EtherTypeIPv4,
+				Payload:     []byte{1, 2, 3},
+			},
+		},
+		{
+			desc: "VLANs, PadByte, MinSize, and Trailer",
+			f: &Frame{
+				Destination: Broadcast,
+				Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+				VLAN: []*VLAN{
+					{ID: 10, TPID: EtherTypeServiceVLAN},
+					{ID: 20, Priority: PriorityVoice, DropEligible: true},
+				},
+				EtherType: EtherTypeIPv6,
+				Payload:   []byte{1, 2, 3},
+				PadByte:   0xa5,
+				MinSize:   80,
+				Trailer:   []byte{0xaa, 0xbb},
+				Meta:      map[string]interface{}{"a": 1, "b": 2, "c": 3},
+			},
+		},
+		{
+			desc: "no addresses",
+			f: &Frame{
+				EtherType: EtherTypeARP,
+				Payload:   bytes.Repeat([]byte{0xff}, 100),
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			want, err := tt.f.MarshalBinary()
+			if err != nil {
+				t.Fatalf("[%02d] test %q, failed to marshal Frame: %v",
+					i, tt.desc, err)
+			}
+
+			// Identical Frames, including clones, must always produce
+			// identical bytes, whether or not memory is reused.
+			buf := make([]byte, len(want))
+			for j := 0; j < 100; j++ {
+				b, err := tt.f.Clone().MarshalBinary()
+				if err != nil {
+					t.Fatalf("[%02d] test %q, failed to marshal Frame: %v",
+						i, tt.desc, err)
+				}
+
+				if got := b; !bytes.Equal(want, got) {
+					t.Fatalf("[%02d] test %q, unexpected Frame bytes on iteration %d:\n- want: %v\n-  got: %v",
+						i, tt.desc, j, want, got)
+				}
+
+				// Dirty the buffer before reusing it.
+				for k := range buf {
+					buf[k] = byte(j)
+				}
+
+				if _, err := tt.f.MarshalBinaryTo(buf); err != nil {
+					t.Fatalf("[%02d] test %q, failed to marshal Frame into buffer: %v",
+						i, tt.desc, err)
+				}
+
+				if got := buf; !bytes.Equal(want, got) {
+					t.Fatalf("[%02d] test %q, unexpected reused buffer bytes on iteration %d:\n- want: %v\n-  got: %v",
+						i, tt.desc, j, want, got)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFrameMarshalBinaryTo(b *testing.B) {
+	f := &Frame{
+		Destination: net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+		Source:      net.HardwareAddr{0xad, 0xbe, 0xef, 0xde, 0xad, 0xde},
+		Payload:     []byte{0, 1, 2, 3, 4},
+	}
+
+	buf := make([]byte, f.Length())
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := f.MarshalBinaryTo(buf); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestFrameMarshalBinaryIgnoresTimestamp(t *testing.T) {
+	f := &Frame{
+		Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+		Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+		EtherType:   EtherTypeIPv4,
+		Payload:     bytes.Repeat([]byte{0}, 50),
+	}
+
+	want, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+
+	f.Timestamp = time.Unix(1, 0)
+	got, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame with Timestamp: %v", err)
+	}
+
+	if !bytes.Equal(want, got) {
+		t.Fatalf("unexpected Frame bytes:\n- want: %v\n- got: %v", want, got)
+	}
+}
+
+func TestFrameHeaderOverhead(t *testing.T) {
+	var tests = []struct {
+		desc string
+		f    *Frame
+		n    int
+	}{
+		{
+			desc: "no VLANs",
+			f: &Frame{
+				Payload: make([]byte, 100),
+			},
+			n: 14,
+		},
+		{
+			desc: "1 VLAN",
+			f: &Frame{
+				VLAN: []*VLAN{{}},
+			},
+			n: 18,
+		},
+		{
+			desc: "2 VLANs",
+			f: &Frame{
+				VLAN: []*VLAN{{}, {}},
+			},
+			n: 22,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if want, got := tt.n, tt.f.HeaderOverhead(); want != got {
+				t.Fatalf("[%02d] test %q, unexpected header overhead: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameProtocol(t *testing.T) {
+	var tests = []struct {
+		desc string
+		f    *Frame
+		s    string
+	}{
+		{
+			desc: "IPv4",
+			f: &Frame{
+				EtherType: EtherTypeIPv4,
+			},
+			s: "IPv4",
+		},
+		{
+			desc: "IPv6, VLAN tagged",
+			f: &Frame{
+				VLAN:      []*VLAN{{ID: 10}},
+				EtherType: EtherTypeIPv6,
+			},
+			s: "IPv6",
+		},
+		{
+			desc: "unknown",
+			f: &Frame{
+				EtherType: 0x9999,
+			},
+			s: "0x9999",
+		},
+		{
+			desc: "IEEE 802.3 length",
+			f: &Frame{
+				EtherType: 0x002e,
+			},
+			s: "0x002e",
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if want, got := tt.s, tt.f.Protocol(); want != got {
+				t.Fatalf("[%02d] test %q, unexpected protocol: %q != %q",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameSummary(t *testing.T) {
+	var tests = []struct {
+		desc string
+		f    *Frame
+		s    string
+	}{
+		{
+			desc: "ARP, broadcast",
+			f: &Frame{
+				Destination: Broadcast,
+				Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+				EtherType:   EtherTypeARP,
+				Payload:     make([]byte, 28),
+			},
+			s: "00:16:3e:44:55:66 > ff:ff:ff:ff:ff:ff, ethertype ARP (0x0806), length 60",
+		},
+		{
+			desc: "unknown EtherType",
+			f: &Frame{
+				Destination: net.HardwareAddr{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33},
+				Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+				EtherType:   0x9999,
+				Payload:     make([]byte, 84),
+			},
+			s: "00:16:3e:44:55:66 > 00:16:3e:11:22:33, ethertype Unknown (0x9999), length 98",
+		},
+		{
+			desc: "IPv6, 802.1Q",
+			f: &Frame{
+				Destination: net.HardwareAddr{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33},
+				Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+				VLAN: []*VLAN{{
+					Priority: PriorityVoice,
+					ID:       100,
+				}},
+				EtherType: EtherTypeIPv6,
+				Payload:   make([]byte, 46),
+			},
+			s: "00:16:3e:44:55:66 > 00:16:3e:11:22:33, ethertype 802.1Q (0x8100), length 64: vlan 100, p 5, ethertype IPv6 (0x86dd)",
+		},
+		{
+			desc: "IPv4, Q-in-Q",
+			f: &Frame{
+				Destination: net.HardwareAddr{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33},
+				Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+				VLAN: []*VLAN{
+					{
+						DropEligible: true,
+						ID:           10,
+					},
+					{
+						Priority: PriorityCriticalApplications,
+						ID:       4094,
+					},
+				},
+				EtherType: EtherTypeIPv4,
+			},
+			s: "00:16:3e:44:55:66 > 00:16:3e:11:22:33, ethertype 802.1Q (0x8100), length 68: vlan 10, p 0, DEI, ethertype 802.1Q (0x8100), vlan 4094, p 3, ethertype IPv4 (0x0800)",
+		},
+		{
+			desc: "IPv4, legacy Q-in-Q",
+			f: &Frame{
+				Destination: net.HardwareAddr{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33},
+				Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+				VLAN: []*VLAN{
+					{
+						ID:   200,
+						TPID: EtherTypeQinQ,
+					},
+					{
+						ID: 300,
+					},
+				},
+				EtherType: EtherTypeIPv4,
+			},
+			s: "00:16:3e:44:55:66 > 00:16:3e:11:22:33, ethertype 802.1Q-9100 (0x9100), length 68: vlan 200, p 0, ethertype 802.1Q (0x8100), vlan 300, p 0, ethertype IPv4 (0x0800)",
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if want, got := tt.s, tt.f.Summary(); want != got {
+				t.Fatalf("[%02d] test %q, unexpected summary:\n- want: %s\n-  got: %s",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameBuffers(t *testing.T) {
+	var tests = []struct {
+		desc string
+		f    *Frame
+		n    int
+		err  error
+	}{
+		{
+			desc: "invalid VLAN",
+			f: &Frame{
+				VLAN: []*VLAN{{ID: VLANMax}},
+			},
+			err: ErrInvalidVLAN,
+		},
+		{
+			desc: "empty payload",
+			f: &Frame{
+				Destination: Broadcast,
+				Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+				EtherType:   EtherTypeARP,
+				PadByte:     0xff,
+			},
+			n: 2,
+		},
+		{
+			desc: "short payload, VLAN",
+			f: &Frame{
+				Destination: Broadcast,
+				Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+				VLAN:        []*VLAN{{ID: 10}},
+				EtherType:   EtherTypeIPv4,
+				Payload:     []byte{0x01, 0x02, 0x03},
+			},
+			n: 3,
+		},
+		{
+			desc: "full payload",
+			f: &Frame{
+				Destination: Broadcast,
+				Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+				EtherType:   EtherTypeIPv6,
+				Payload:     bytes.Repeat([]byte{0xaa}, 100),
+			},
+			n: 2,
+		},
+		{
+			desc: "short payload, trailer",
+			f: &Frame{
+				Destination: Broadcast,
+				Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+				EtherType:   EtherTypeIPv4,
+				Payload:     []byte{0x01, 0x02, 0x03},
+				Trailer:     []byte{0xca, 0xfe},
+			},
+			n: 4,
+		},
+		{
+			desc: "full payload, MinSize",
+			f: &Frame{
+				Destination: Broadcast,
+				Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+				EtherType:   EtherTypeIPv4,
+				Payload:     bytes.Repeat([]byte{0xaa}, 46),
+				MinSize:     64,
+			},
+			n: 3,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			bufs, err := tt.f.Buffers()
+			if want, got := tt.err, err; want != got {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+			if err != nil {
+				return
+			}
+
+			if want, got := tt.n, len(bufs); want != got {
+				t.Fatalf("[%02d] test %q, unexpected number of buffers: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if len(tt.f.Payload) > 0 && &bufs[1][0] != &tt.f.Payload[0] {
+				t.Fatalf("[%02d] test %q, payload buffer does not alias Payload",
+					i, tt.desc)
+			}
+
+			want, err := tt.f.MarshalBinary()
+			if err != nil {
+				t.Fatalf("[%02d] test %q, failed to marshal Frame: %v",
+					i, tt.desc, err)
+			}
+
+			var got bytes.Buffer
+			if _, err := bufs.WriteTo(&got); err != nil {
+				t.Fatalf("[%02d] test %q, failed to write buffers: %v",
+					i, tt.desc, err)
+			}
+
+			if !bytes.Equal(want, got.Bytes()) {
+				t.Fatalf("[%02d] test %q, unexpected Frame bytes:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got.Bytes())
+			}
+		})
+	}
+}
+
+func TestFrameRoundTripManyVLANs(t *testing.T) {
+	var tests = []struct {
+		desc string
+		vlan []*VLAN
+		b    []byte
+	}{
+		{
+			desc: "triple tagged",
+			vlan: []*VLAN{
+				{Priority: 1, ID: 10},
+				{Priority: 2, ID: 20},
+				{Priority: 3, DropEligible: true, ID: 30},
+			},
+			b: []byte{
+				0x81, 0x00, 0x20, 0x0a,
+				0x81, 0x00, 0x40, 0x14,
+				0x81, 0x00, 0x70, 0x1e,
+			},
+		},
+		{
+			desc: "quadruple tagged",
+			vlan: []*VLAN{
+				{Priority: 1, ID: 10},
+				{Priority: 2, ID: 20},
+				{Priority: 3, DropEligible: true, ID: 30},
+				{Priority: 7, ID: 4094},
+			},
+			b: []byte{
+				0x81, 0x00, 0x20, 0x0a,
+				0x81, 0x00, 0x40, 0x14,
+				0x81, 0x00, 0x70, 0x1e,
+				0x81, 0x00, 0xef, 0xfe,
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f := &Frame{
+				Destination: net.HardwareAddr{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33},
+				Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+				VLAN:        tt.vlan,
+				EtherType:   EtherTypeIPv4,
+				Payload:     vectorPayload(46),
+			}
+
+			want := append([]byte{
+				0x00, 0x16, 0x3e, 0x11, 0x22, 0x33,
+				0x00, 0x16, 0x3e, 0x44, 0x55, 0x66,
+			}, tt.b...)
+			want = append(want, 0x08, 0x00)
+			want = append(want, vectorPayload(46)...)
+
+			if want, got := len(want), f.Length(); want != got {
+				t.Fatalf("[%02d] test %q, unexpected length: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			b, err := f.MarshalBinary()
+			if err != nil {
+				t.Fatalf("[%02d] test %q, failed to marshal Frame: %v",
+					i, tt.desc, err)
+			}
+
+			if !bytes.Equal(want, b) {
+				t.Fatalf("[%02d] test %q, unexpected Frame bytes:\n- want: %v\n- got: %v",
+					i, tt.desc, want, b)
+			}
+
+			f2 := new(Frame)
+			if err := f2.UnmarshalBinary(b); err != nil {
+				t.Fatalf("[%02d] test %q, failed to unmarshal Frame: %v",
+					i, tt.desc, err)
+			}
+
+			if !reflect.DeepEqual(f, f2) {
+				t.Fatalf("[%02d] test %q, unexpected Frame:\n- want: %v\n- got: %v",
+					i, tt.desc, f, f2)
+			}
+		})
+	}
+}
+
+func TestFrameClone(t *testing.T) {
+	var tests = []struct {
+		desc string
+		f    *Frame
+	}{
+		{
+			desc: "empty",
+			f:    &Frame{},
+		},
+		{
+			desc: "Q-in-Q",
+			f: &Frame{
+				Destination: net.HardwareAddr{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33},
+				Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+				VLAN: []*VLAN{
+					{ID: 10},
+					{Priority: PriorityVoice, ID: 20},
+				},
+				EtherType: EtherTypeIPv4,
+				Payload:   []byte{0xde, 0xad, 0xbe, 0xef},
+				PadByte:   0xff,
+				Timestamp: time.Unix(1, 0),
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := tt.f.Clone()
+			if !reflect.DeepEqual(tt.f, c) {
+				t.Fatalf("[%02d] test %q, unexpected Frame:\n- want: %v\n- got: %v",
+					i, tt.desc, tt.f, c)
+			}
+
+			if len(c.VLAN) == 0 {
+				return
+			}
+
+			// Modify the copy, and ensure the original is unchanged.
+			want := tt.f.Clone()
+			c.Destination[0] = 0xff
+			c.Source[0] = 0xff
+			c.Payload[0] = 0xff
+			c.VLAN[0].ID = 4000
+
+			if !reflect.DeepEqual(want, tt.f) {
+				t.Fatalf("[%02d] test %q, original Frame was modified:\n- want: %v\n- got: %v",
+					i, tt.desc, want, tt.f)
+			}
+		})
+	}
+}
+
+func TestFrameMeta(t *testing.T) {
+	f := &Frame{
+		Destination: net.HardwareAddr{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33},
+		Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+		EtherType:   EtherTypeIPv4,
+		Payload:     []byte{0xde, 0xad, 0xbe, 0xef},
+	}
+
+	want, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+
+	f.Meta = map[string]interface{}{
+		"ingress":  3,
+		"decision": "forward",
+	}
+
+	// Meta is not part of the binary form.
+	got, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame with Meta: %v", err)
+	}
+	if !bytes.Equal(want, got) {
+		t.Fatalf("unexpected Frame bytes:\n- want: %v\n- got: %v", want, got)
+	}
+
+	// Meta survives unmarshaling, and is copied by Clone.
+	if err := f.UnmarshalBinary(got); err != nil {
+		t.Fatalf("failed to unmarshal Frame: %v", err)
+	}
+
+	c := f.Clone()
+	c.Meta["decision"] = "drop"
+
+	if want, got := "forward", f.Meta["decision"]; want != got {
+		t.Fatalf("unexpected metadata: %v != %v", want, got)
+	}
+	if !f.Equal(c) {
+		t.Fatal("Frames differing only by Meta are not equal")
+	}
+}
+
+func TestFrameReverse(t *testing.T) {
+	f := &Frame{
+		Destination: net.HardwareAddr{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33},
+		Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+		VLAN: []*VLAN{
+			{ID: 10},
+		},
+		EtherType: EtherTypeIPv4,
+		Payload:   []byte{0xde, 0xad, 0xbe, 0xef},
+	}
+
+	want := &Frame{
+		Destination: net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+		Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33},
+		VLAN: []*VLAN{
+			{ID: 10},
+		},
+		EtherType: EtherTypeIPv4,
+		Payload:   []byte{0xde, 0xad, 0xbe, 0xef},
+	}
+
+	r := f.Reverse()
+	if !reflect.DeepEqual(want, r) {
+		t.Fatalf("unexpected Frame:\n- want: %v\n- got: %v", want, r)
+	}
+
+	// Modify the reply, and ensure the original is unchanged.
+	orig := f.Clone()
+	r.Destination[0] = 0xff
+	r.Source[0] = 0xff
+	r.Payload[0] = 0xff
+	r.VLAN[0].ID = 4000
+
+	if !reflect.DeepEqual(orig, f) {
+		t.Fatalf("original Frame was modified:\n- want: %v\n- got: %v", orig, f)
+	}
+}
+
+func TestFrameMarshalBadFCS(t *testing.T) {
+	for i, tt := range TestVectors {
+		t.Run(tt.Name, func(t *testing.T) {
+			good, err := tt.Frame.MarshalFCS()
+			if err != nil {
+				t.Fatalf("[%02d] test %q, failed to marshal Frame with FCS: %v",
+					i, tt.Name, err)
+			}
+
+			bad, err := tt.Frame.MarshalBadFCS()
+			if err != nil {
+				t.Fatalf("[%02d] test %q, failed to marshal Frame with bad FCS: %v",
+					i, tt.Name, err)
+			}
+
+			// Only the frame check sequence differs, and every bit is
+			// inverted.
+			if want, got := good[:len(good)-4], bad[:len(bad)-4]; !bytes.Equal(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected Frame bytes:\n- want: %v\n- got: %v",
+					i, tt.Name, want, got)
+			}
+			want := binary.BigEndian.Uint32(good[len(good)-4:]) ^ 0xffffffff
+			if got := binary.BigEndian.Uint32(bad[len(bad)-4:]); want != got {
+				t.Fatalf("[%02d] test %q, unexpected FCS: %#08x != %#08x",
+					i, tt.Name, want, got)
+			}
+
+			if err := new(Frame).UnmarshalFCS(bad); err != ErrInvalidFCS {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.Name, ErrInvalidFCS, err)
+			}
+		})
+	}
+
+	if _, err := (&Frame{VLAN: []*VLAN{{ID: VLANMax}}}).MarshalBadFCS(); err != ErrInvalidVLAN {
+		t.Fatalf("unexpected error: %v != %v", ErrInvalidVLAN, err)
+	}
+}
+
+func TestFrameMarshalWithFCS(t *testing.T) {
+	var tests = []struct {
+		desc string
+		f    *Frame
+		fcs  uint32
+		b    []byte
+		err  error
+	}{
+		{
+			desc: "VLAN priority too large",
+			f: &Frame{
+				VLAN: []*VLAN{{
+					Priority: 8,
+				}},
+			},
+			err: ErrInvalidVLAN,
+		},
+		{
+			desc: "IPv4, incorrect FCS",
+			f: &Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				EtherType:   EtherTypeIPv4,
+				Payload:     bytes.Repeat([]byte{0}, 50),
+			},
+			fcs: 0xdeadbeef,
+			b: append(
+				append(
+					[]byte{
+						0, 1, 0, 1, 0, 1,
+						1, 0, 1, 0, 1, 0,
+						0x08, 0x00,
+					},
+					bytes.Repeat([]byte{0}, 50)...,
+				),
+				[]byte{0xde, 0xad, 0xbe, 0xef}...,
+			),
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			b, err := tt.f.MarshalWithFCS(tt.fcs)
+			if want, got := tt.err, err; want != got {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.b, b; !bytes.Equal(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected Frame bytes:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameSizeConstants(t *testing.T) {
+	var tests = []struct {
+		desc  string
+		vlans int
+		size  int
+	}{
+		{
+			desc: "untagged",
+			size: MaxFrameSize,
+		},
+		{
+			desc:  "802.1Q",
+			vlans: 1,
+			size:  MaxFrameSizeVLAN,
+		},
+		{
+			desc:  "Q-in-Q",
+			vlans: 2,
+			size:  MaxFrameSizeQinQ,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f := &Frame{
+				VLAN:    make([]*VLAN, tt.vlans),
+				Payload: make([]byte, 1500),
+			}
+
+			// Include 4 bytes for the frame check sequence.
+			if want, got := tt.size, f.Length()+4; want != got {
+				t.Fatalf("[%02d] test %q, unexpected maximum frame size: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+
+	if want, got := MinFrameSize, (&Frame{}).Length()+4; want != got {
+		t.Fatalf("unexpected minimum frame size: %v != %v", want, got)
+	}
+}
+
+func TestFrameFitsMTU(t *testing.T) {
+	var tests = []struct {
+		desc string
+		f    *Frame
+		mtu  int
+		ok   bool
+	}{
+		{
+			desc: "empty",
+			f:    &Frame{},
+			mtu:  1500,
+			ok:   true,
+		},
+		{
+			desc: "full payload",
+			f: &Frame{
+				Payload: make([]byte, 1500),
+			},
+			mtu: 1500,
+			ok:  true,
+		},
+		{
+			desc: "payload too large",
+			f: &Frame{
+				Payload: make([]byte, 1501),
+			},
+			mtu: 1500,
+		},
+		{
+			desc: "full payload, VLAN",
+			f: &Frame{
+				VLAN:    []*VLAN{{ID: 10}},
+				Payload: make([]byte, 1500),
+			},
+			mtu: 1500,
+			ok:  true,
+		},
+		{
+			desc: "full payload, Q-in-Q",
+			f: &Frame{
+				VLAN:    []*VLAN{{ID: 10}, {ID: 20}},
+				Payload: make([]byte, 1500),
+			},
+			mtu: 1500,
+		},
+		{
+			desc: "full payload, trailer",
+			f: &Frame{
+				Payload: make([]byte, 1500),
+				Trailer: []byte{0xaa},
+			},
+			mtu: 1500,
+		},
+		{
+			desc: "payload and trailer, larger MTU",
+			f: &Frame{
+				Payload: make([]byte, 1500),
+				Trailer: make([]byte, 4),
+			},
+			mtu: 1504,
+			ok:  true,
+		},
+		{
+			desc: "full payload, Q-in-Q, larger MTU",
+			f: &Frame{
+				VLAN:    []*VLAN{{ID: 10}, {ID: 20}},
+				Payload: make([]byte, 1500),
+			},
+			mtu: 1504,
+			ok:  true,
+		},
+		{
+			desc: "jumbo",
+			f: &Frame{
+				Payload: make([]byte, 9000),
+			},
+			mtu: 9000,
+			ok:  true,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if want, got := tt.ok, tt.f.FitsMTU(tt.mtu); want != got {
+				t.Fatalf("[%02d] test %q, unexpected FitsMTU: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameUnmarshalFCSN(t *testing.T) {
+	fs := []*Frame{
+		{
+			Destination: net.HardwareAddr{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33},
+			Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+			EtherType:   EtherTypeIPv4,
+			Payload:     vectorPayload(46),
+		},
+		{
+			Destination: Broadcast,
+			Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+			VLAN:        []*VLAN{{ID: 10}},
+			EtherType:   EtherTypeIPv6,
+			Payload:     vectorPayload(100),
+		},
+	}
+
+	var stream []byte
+	for _, f := range fs {
+		b, err := f.MarshalFCS()
+		if err != nil {
+			t.Fatalf("failed to marshal Frame: %v", err)
+		}
+
+		stream = append(stream, b...)
+	}
+
+	b := stream
+	for i, want := range fs {
+		got := new(Frame)
+		n, err := got.UnmarshalFCSN(b)
+		if err != nil {
+			t.Fatalf("[%02d] failed to unmarshal Frame: %v", i, err)
+		}
+
+		if want, got := want.Length()+4, n; want != got {
+			t.Fatalf("[%02d] unexpected number of bytes consumed: %v != %v",
+				i, want, got)
+		}
+
+		if !reflect.DeepEqual(want, got) {
+			t.Fatalf("[%02d] unexpected Frame:\n- want: %v\n- got: %v",
+				i, want, got)
+		}
+
+		b = b[n:]
+	}
+
+	if len(b) != 0 {
+		t.Fatalf("unexpected trailing bytes: %v", b)
+	}
+
+	if _, err := new(Frame).UnmarshalFCSN(stream[:MinFrameSize-1]); err != io.ErrUnexpectedEOF {
+		t.Fatalf("unexpected error for short buffer: %v", err)
+	}
+
+	bad := append([]byte(nil), stream[:MinFrameSize]...)
+	bad[len(bad)-1]++
+	if _, err := new(Frame).UnmarshalFCSN(bad); err != ErrInvalidFCS {
+		t.Fatalf("unexpected error for invalid FCS: %v", err)
+	}
+}
+
+func TestFrameFCSUntagged(t *testing.T) {
+	untagged := &Frame{
+		Destination: net.HardwareAddr{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33},
+		Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+		EtherType:   EtherTypeIPv4,
+		Payload:     vectorPayload(46),
+	}
+
+	tagged := untagged.Clone()
+	tagged.VLAN = []*VLAN{{ID: 10}, {Priority: PriorityVoice, ID: 20}}
+
+	ub, err := untagged.MarshalFCS()
+	if err != nil {
+		t.Fatalf("failed to marshal untagged Frame: %v", err)
+	}
+
+	b, err := tagged.MarshalFCSUntagged()
+	if err != nil {
+		t.Fatalf("failed to marshal tagged Frame: %v", err)
+	}
+
+	// The frame check sequence must match that of the untagged Frame.
+	if want, got := ub[len(ub)-4:], b[len(b)-4:]; !bytes.Equal(want, got) {
+		t.Fatalf("unexpected FCS: %v != %v", want, got)
+	}
+
+	if err := new(Frame).UnmarshalFCS(b); err != ErrInvalidFCS {
+		t.Fatalf("expected standard FCS to be invalid, but got: %v", err)
+	}
+
+	f := new(Frame)
+	if err := f.UnmarshalFCSUntagged(b); err != nil {
+		t.Fatalf("failed to unmarshal tagged Frame: %v", err)
+	}
+
+	if !reflect.DeepEqual(tagged, f) {
+		t.Fatalf("unexpected Frame:\n- want: %v\n- got: %v", tagged, f)
+	}
+
+	b[len(b)-1]++
+	if err := f.UnmarshalFCSUntagged(b); err != ErrInvalidFCS {
+		t.Fatalf("unexpected error for invalid FCS: %v", err)
+	}
+
+	if err := f.UnmarshalFCSUntagged([]byte{0, 0, 0}); err != io.ErrUnexpectedEOF {
+		t.Fatalf("unexpected error for short buffer: %v", err)
+	}
+
+	if _, err := (&Frame{VLAN: []*VLAN{{ID: VLANMax}}}).MarshalFCSUntagged(); err != ErrInvalidVLAN {
+		t.Fatalf("unexpected error for invalid VLAN: %v", err)
+	}
+}
+
+func TestKnownEtherTypes(t *testing.T) {
+	want := []EtherType{
+		EtherTypeIPv4,
+		EtherTypeARP,
+		EtherTypeTransparentBridging,
+		EtherTypeVLAN,
+		EtherTypeIPv6,
+		EtherTypeMACControl,
+		EtherTypeSlowProtocols,
+		EtherTypeServiceVLAN,
+		EtherTypeLocalExperimental1,
+		EtherTypeLocalExperimental2,
+		EtherTypeLLDP,
+		EtherTypeITag,
+		EtherTypeQinQ,
+		EtherTypeQinQAlt,
+	}
+
+	got := KnownEtherTypes()
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected EtherTypes:\n- want: %v\n- got: %v", want, got)
+	}
+
+	// Modifying the result must not affect later calls.
+	got[0] = 0
+	if got := KnownEtherTypes(); !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected EtherTypes after modification:\n- want: %v\n- got: %v", want, got)
+	}
+}
+
+func TestKnownEtherTypesNamed(t *testing.T) {
+	ets := KnownEtherTypes()
+	for i, e := range ets {
+		// Each EtherType must have a name generated by stringer, rather
+		// than the numeric fallback.
+		if s := e.String(); strings.HasPrefix(s, "EtherType(") {
+			t.Fatalf("[%02d] EtherType %#04x has no name: %q", i, uint16(e), s)
+		}
+
+		if i > 0 && ets[i-1] >= e {
+			t.Fatalf("[%02d] EtherTypes not sorted: %#04x >= %#04x",
+				i, uint16(ets[i-1]), uint16(e))
+		}
+	}
+}
+
+func TestEtherTypeEqualName(t *testing.T) {
+	var tests = []struct {
+		desc string
+		e    EtherType
+		s    string
+		ok   bool
+	}{
+		{
+			desc: "short name",
+			e:    EtherTypeIPv4,
+			s:    "IPv4",
+			ok:   true,
+		},
+		{
+			desc: "short name, lower case",
+			e:    EtherTypeIPv4,
+			s:    "ipv4",
+			ok:   true,
+		},
+		{
+			desc: "short name, upper case",
+			e:    EtherTypeARP,
+			s:    "ARP",
+			ok:   true,
+		},
+		{
+			desc: "constant name",
+			e:    EtherTypeLLDP,
+			s:    "EtherTypeLLDP",
+			ok:   true,
+		},
+		{
+			desc: "constant name, mixed case",
+			e:    EtherTypeServiceVLAN,
+			s:    "ethertypeservicevlan",
+			ok:   true,
+		},
+		{
+			desc: "other EtherType",
+			e:    EtherTypeIPv6,
+			s:    "IPv4",
+		},
+		{
+			desc: "prefix only",
+			e:    EtherTypeIPv4,
+			s:    "EtherType",
+		},
+		{
+			desc: "empty",
+			e:    EtherTypeIPv4,
+		},
+		{
+			desc: "unknown EtherType",
+			e:    0x88b7,
+			s:    "EtherType(34999)",
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if want, got := tt.ok, tt.e.EqualName(tt.s); want != got {
+				t.Fatalf("[%02d] test %q, unexpected EqualName(%q): %v != %v",
+					i, tt.desc, tt.s, want, got)
+			}
+		})
+	}
+}
+
+func TestEtherTypeIsExperimental(t *testing.T) {
+	var tests = []struct {
+		desc string
+		e    EtherType
+		ok   bool
+	}{
+		{
+			desc: "IPv4",
+			e:    EtherTypeIPv4,
+		},
+		{
+			desc: "below range",
+			e:    0x88b4,
+		},
+		{
+			desc: "local experimental 1",
+			e:    EtherTypeLocalExperimental1,
+			ok:   true,
+		},
+		{
+			desc: "local experimental 2",
+			e:    EtherTypeLocalExperimental2,
+			ok:   true,
+		},
+		{
+			desc: "above range",
+			e:    0x88b7,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if want, got := tt.ok, tt.e.IsExperimental(); want != got {
+				t.Fatalf("[%02d] test %q, unexpected IsExperimental: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameUnmarshalBinaryVLANTPIDs(t *testing.T) {
+	b := []byte{
+		0, 1, 0, 1, 0, 1,
+		1, 0, 1, 0, 1, 0,
+		0x92, 0x00,
+		0x00, 0x64,
+		0x08, 0x00,
+		0xde, 0xad,
+	}
+
+	f := new(Frame)
+	if err := f.UnmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal Frame: %v", err)
+	}
+
+	want := []*VLAN{{
+		ID:   100,
+		TPID: EtherTypeQinQAlt,
+	}}
+	if got := f.VLAN; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected VLANs:\n- want: %v\n- got: %v", want, got)
+	}
+
+	// Once 0x9200 is no longer recognized, it is treated as an EtherType.
+	defer func(tpids []EtherType) { VLANTPIDs = tpids }(VLANTPIDs)
+	VLANTPIDs = []EtherType{EtherTypeVLAN}
+
+	f = new(Frame)
+	if err := f.UnmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal Frame: %v", err)
+	}
+
+	if len(f.VLAN) != 0 {
+		t.Fatalf("unexpected VLANs: %v", f.VLAN)
+	}
+	if want, got := EtherTypeQinQAlt, f.EtherType; want != got {
+		t.Fatalf("unexpected EtherType: %v != %v", want, got)
+	}
+}
+
+func TestFrameUnmarshalBinaryServiceVLANDefault(t *testing.T) {
+	b := []byte{
+		0, 1, 0, 1, 0, 1,
+		1, 0, 1, 0, 1, 0,
+		0x88, 0xa8,
+		0x00, 0x64,
+		0x81, 0x00,
+		0x00, 0xc8,
+		0x08, 0x00,
+		0xde, 0xad,
+	}
+
+	// By default, an 802.1ad outer tag is decoded as a VLAN tag, rather
+	// than as the EtherType of the Frame.
+	f := new(Frame)
+	if err := f.UnmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal Frame: %v", err)
+	}
+
+	want := &Frame{
+		Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+		Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+		VLAN: []*VLAN{
+			{ID: 100, TPID: EtherTypeServiceVLAN},
+			{ID: 200},
+		},
+		EtherType: EtherTypeIPv4,
+		Payload:   []byte{0xde, 0xad},
+	}
+	if !reflect.DeepEqual(want, f) {
+		t.Fatalf("unexpected Frame:\n- want: %v\n- got: %v", want, f)
+	}
+
+	// Without 0x88a8 in VLANTPIDs, the tags remain in the payload.
+	defer func(tpids []EtherType) { VLANTPIDs = tpids }(VLANTPIDs)
+	VLANTPIDs = []EtherType{EtherTypeVLAN}
+
+	f = new(Frame)
+	if err := f.UnmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal Frame: %v", err)
+	}
+
+	if len(f.VLAN) != 0 {
+		t.Fatalf("unexpected VLANs: %v", f.VLAN)
+	}
+	if want, got := EtherTypeServiceVLAN, f.EtherType; want != got {
+		t.Fatalf("unexpected EtherType: %v != %v", want, got)
+	}
+	if want, got := b[14:], f.Payload; !bytes.Equal(want, got) {
+		t.Fatalf("unexpected payload: %v != %v", want, got)
+	}
+}
+
+func TestAppendFCS(t *testing.T) {
+	for i, tt := range TestVectors {
+		t.Run(tt.Name, func(t *testing.T) {
+			body, err := tt.Frame.MarshalBinary()
+			if err != nil {
+				t.Fatalf("[%02d] test %q, failed to marshal Frame: %v",
+					i, tt.Name, err)
+			}
+
+			want, err := tt.Frame.MarshalFCS()
+			if err != nil {
+				t.Fatalf("[%02d] test %q, failed to marshal Frame with FCS: %v",
+					i, tt.Name, err)
+			}
+
+			if want, got := binary.BigEndian.Uint32(want[len(want)-4:]), FCS(body); want != got {
+				t.Fatalf("[%02d] test %q, unexpected FCS: %#08x != %#08x",
+					i, tt.Name, want, got)
+			}
+
+			if got := AppendFCS(body); !bytes.Equal(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected Frame bytes:\n- want: %v\n- got: %v",
+					i, tt.Name, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameWriteFCS(t *testing.T) {
+	for i, tt := range TestVectors {
+		t.Run(tt.Name, func(t *testing.T) {
+			want, err := tt.Frame.MarshalFCS()
+			if err != nil {
+				t.Fatalf("[%02d] test %q, failed to marshal Frame with FCS: %v",
+					i, tt.Name, err)
+			}
+
+			var buf bytes.Buffer
+			n, fcs, err := tt.Frame.WriteFCS(&buf)
+			if err != nil {
+				t.Fatalf("[%02d] test %q, failed to write Frame: %v",
+					i, tt.Name, err)
+			}
+
+			if want, got := int64(len(want)), n; want != got {
+				t.Fatalf("[%02d] test %q, unexpected number of bytes: %v != %v",
+					i, tt.Name, want, got)
+			}
+			if want, got := binary.BigEndian.Uint32(want[len(want)-4:]), fcs; want != got {
+				t.Fatalf("[%02d] test %q, unexpected FCS: %#08x != %#08x",
+					i, tt.Name, want, got)
+			}
+			if got := buf.Bytes(); !bytes.Equal(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected Frame bytes:\n- want: %v\n- got: %v",
+					i, tt.Name, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameWriteFCSErrors(t *testing.T) {
+	f := &Frame{
+		VLAN: []*VLAN{{ID: VLANMax}},
+	}
+
+	var buf bytes.Buffer
+	if _, _, err := f.WriteFCS(&buf); err != ErrInvalidVLAN {
+		t.Fatalf("unexpected error: %v != %v", ErrInvalidVLAN, err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("unexpected bytes written: %v", buf.Bytes())
+	}
+
+	// A write error is returned with the number of bytes written.
+	n, fcs, err := TestVectors[0].Frame.WriteFCS(&limitWriter{n: 20})
+	if err != io.ErrShortWrite {
+		t.Fatalf("unexpected error: %v != %v", io.ErrShortWrite, err)
+	}
+	if n != 20 || fcs != 0 {
+		t.Fatalf("unexpected results: n = %d, fcs = %#08x", n, fcs)
+	}
+}
+
+// A limitWriter is an io.Writer which accepts n bytes, and then returns
+// io.ErrShortWrite.
+type limitWriter struct {
+	n int
+}
+
+func (w *limitWriter) Write(b []byte) (int, error) {
+	if len(b) <= w.n {
+		w.n -= len(b)
+		return len(b), nil
+	}
+
+	n := w.n
+	w.n = 0
+	return n, io.ErrShortWrite
+}
+
+func TestIdentifyFCS(t *testing.T) {
+	body, err := TestVectors[0].Frame.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+
+	castagnoli, err := TestVectors[0].Frame.MarshalFCSHash(crc32.New(crc32.MakeTable(crc32.Castagnoli)))
+	if err != nil {
+		t.Fatalf("failed to marshal Frame with FCS: %v", err)
+	}
+
+	var tests = []struct {
+		desc       string
+		b          []byte
+		ieee       bool
+		castagnoli bool
+	}{
+		{
+			desc: "short",
+			b:    []byte{0, 0, 0},
+		},
+		{
+			desc: "no FCS",
+			b:    body,
+		},
+		{
+			desc: "IEEE",
+			b:    AppendFCS(append([]byte(nil), body...)),
+			ieee: true,
+		},
+		{
+			desc:       "Castagnoli",
+			b:          castagnoli,
+			castagnoli: true,
+		},
+		{
+			// The CRC of an empty body is 0 for both polynomials.
+			desc:       "both",
+			b:          []byte{0, 0, 0, 0},
+			ieee:       true,
+			castagnoli: true,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ieee, castagnoli := IdentifyFCS(tt.b)
+			if want, got := tt.ieee, ieee; want != got {
+				t.Fatalf("[%02d] test %q, unexpected IEEE result: %v != %v",
+					i, tt.desc, want, got)
+			}
+			if want, got := tt.castagnoli, castagnoli; want != got {
+				t.Fatalf("[%02d] test %q, unexpected Castagnoli result: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameDeclaredSize(t *testing.T) {
+	var tests = []struct {
+		desc     string
+		f        *Frame
+		declared int
+		length   int
+	}{
+		{
+			desc:     "empty",
+			f:        &Frame{},
+			declared: 14,
+			length:   60,
+		},
+		{
+			desc: "short payload, VLAN",
+			f: &Frame{
+				VLAN:    []*VLAN{{ID: 10}},
+				Payload: make([]byte, 10),
+			},
+			declared: 28,
+			length:   64,
+		},
+		{
+			desc: "full payload",
+			f: &Frame{
+				Payload: make([]byte, 1500),
+			},
+			declared: 1514,
+			length:   1514,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if want, got := tt.declared, tt.f.DeclaredSize(); want != got {
+				t.Fatalf("[%02d] test %q, unexpected declared size: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.length, tt.f.Length(); want != got {
+				t.Fatalf("[%02d] test %q, unexpected length: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameMarshalForInterface(t *testing.T) {
+	var tests = []struct {
+		desc string
+		f    *Frame
+		mtu  int
+		fcs  bool
+		err  error
+	}{
+		{
+			desc: "payload too large",
+			f: &Frame{
+				Payload: make([]byte, 1501),
+			},
+			mtu: 1500,
+			err: ErrFrameTooLarge,
+		},
+		{
+			desc: "VLAN tags exceed MTU",
+			f: &Frame{
+				VLAN:    []*VLAN{{ID: 10}, {ID: 20}},
+				Payload: make([]byte, 1500),
+			},
+			mtu: 1503,
+			err: ErrFrameTooLarge,
+		},
+		{
+			desc: "trailer exceeds MTU",
+			f: &Frame{
+				Destination: Broadcast,
+				Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+				EtherType:   EtherTypeIPv4,
+				Payload:     make([]byte, 1498),
+				Trailer:     make([]byte, 4),
+			},
+			mtu: 1500,
+			err: ErrFrameTooLarge,
+		},
+		{
+			desc: "FCS exceeds MTU",
+			f: &Frame{
+				Destination: Broadcast,
+				Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+				EtherType:   EtherTypeIPv4,
+				Payload:     make([]byte, 1500),
+			},
+			mtu: 1500,
+			fcs: true,
+			err: ErrFrameTooLarge,
+		},
+		{
+			desc: "invalid VLAN",
+			f: &Frame{
+				VLAN: []*VLAN{{ID: VLANMax}},
+			},
+			mtu: 1500,
+			err: ErrInvalidVLAN,
+		},
+		{
+			desc: "OK",
+			f: &Frame{
+				Destination: Broadcast,
+				Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+				EtherType:   EtherTypeIPv4,
+				Payload:     make([]byte, 1500),
+			},
+			mtu: 1500,
+		},
+		{
+			desc: "OK, VLAN",
+			f: &Frame{
+				Destination: Broadcast,
+				Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+				VLAN:        []*VLAN{{ID: 10}},
+				EtherType:   EtherTypeIPv4,
+				Payload:     make([]byte, 1500),
+			},
+			mtu: 1500,
+		},
+		{
+			desc: "OK, Q-in-Q",
+			f: &Frame{
+				Destination: Broadcast,
+				Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+				VLAN:        []*VLAN{{ID: 10}, {ID: 20}},
+				EtherType:   EtherTypeIPv4,
+				Payload:     make([]byte, 1496),
+			},
+			mtu: 1500,
+		},
+		{
+			desc: "OK, FCS",
+			f: &Frame{
+				Destination: Broadcast,
+				Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+				EtherType:   EtherTypeIPv4,
+				Payload:     make([]byte, 1496),
+			},
+			mtu: 1500,
+			fcs: true,
+		},
+		{
+			desc: "OK, VLAN and FCS",
+			f: &Frame{
+				Destination: Broadcast,
+				Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+				VLAN:        []*VLAN{{ID: 10}},
+				EtherType:   EtherTypeIPv4,
+				Payload:     make([]byte, 1496),
+			},
+			mtu: 1500,
+			fcs: true,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			b, err := tt.f.MarshalForInterface(tt.mtu, tt.fcs)
+			if want, got := tt.err, err; !errors.Is(got, want) {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+			if err != nil {
+				return
+			}
+
+			marshal := tt.f.MarshalBinary
+			if tt.fcs {
+				marshal = tt.f.MarshalFCS
+			}
+
+			want, err := marshal()
+			if err != nil {
+				t.Fatalf("[%02d] test %q, failed to marshal Frame: %v",
+					i, tt.desc, err)
+			}
+
+			if !bytes.Equal(want, b) {
+				t.Fatalf("[%02d] test %q, unexpected Frame bytes:\n- want: %v\n- got: %v",
+					i, tt.desc, want, b)
+			}
+		})
+	}
+
+	_, err := (&Frame{Payload: make([]byte, 1510)}).MarshalForInterface(1500, false)
+	if want, got := "frame too large: 1510 bytes exceeds MTU 1500 by 10 bytes", err.Error(); want != got {
+		t.Fatalf("unexpected error message:\n- want: %s\n- got: %s", want, got)
+	}
+}
+
+func TestFramePayloadEntropy(t *testing.T) {
+	all := make([]byte, 256)
+	for i := range all {
+		all[i] = byte(i)
+	}
+
+	var tests = []struct {
+		desc string
+		p    []byte
+		e    float64
+	}{
+		{
+			desc: "empty",
+		},
+		{
+			desc: "single byte",
+			p:    []byte{0xff},
+		},
+		{
+			desc: "repeated byte",
+			p:    bytes.Repeat([]byte{0xaa}, 100),
+		},
+		{
+			desc: "two bytes",
+			p:    []byte{0x00, 0x01, 0x00, 0x01},
+			e:    1,
+		},
+		{
+			desc: "four bytes",
+			p:    []byte{0x00, 0x01, 0x02, 0x03},
+			e:    2,
+		},
+		{
+			desc: "skewed",
+			p:    []byte{0x00, 0x00, 0x00, 0x01},
+			e:    0.8112781244591328,
+		},
+		{
+			desc: "all bytes",
+			p:    bytes.Repeat(all, 4),
+			e:    8,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f := &Frame{Payload: tt.p}
+			if want, got := tt.e, f.PayloadEntropy(); math.Abs(want-got) > 1e-9 {
+				t.Fatalf("[%02d] test %q, unexpected entropy: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameFlowHash(t *testing.T) {
+	var tests = []struct {
+		desc string
+		f    *Frame
+		h    uint32
+	}{
+		{
+			desc: "IPv4, untagged",
+			f:    TestVectors[0].Frame,
+			h:    0xd094579a,
+		},
+		{
+			desc: "IPv6, 802.1Q",
+			f:    TestVectors[2].Frame,
+			h:    0x59713717,
+		},
+		{
+			desc: "IPv6, Q-in-Q, inner tag and payload ignored",
+			f: &Frame{
+				Destination: TestVectors[2].Frame.Destination,
+				Source:      TestVectors[2].Frame.Source,
+				VLAN: []*VLAN{
+					{ID: 100},
+					{ID: 200},
+				},
+				EtherType: EtherTypeIPv6,
+				Payload:   []byte{0xde, 0xad},
+			},
+			h: 0x59713717,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if want, got := tt.h, tt.f.FlowHash(); want != got {
+				t.Fatalf("[%02d] test %q, unexpected flow hash: %#08x != %#08x",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestUnmarshalAuto(t *testing.T) {
+	short := []byte{
+		0, 1, 0, 1, 0, 1,
+		1, 0, 1, 0, 1, 0,
+		0x08, 0x00,
+		0xde, 0xad,
+	}
+
+	var tests = []struct {
+		desc string
+		b    []byte
+		f    *Frame
+		fcs  bool
+		err  error
+	}{
+		{
+			desc: "short header",
+			b:    short[:13],
+			err:  io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "short frame with valid FCS",
+			b:    AppendFCS(short),
+			f: &Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				EtherType:   EtherTypeIPv4,
+				Payload:     AppendFCS(short)[14:],
+			},
+		},
+		{
+			desc: "no FCS",
+			b:    TestVectors[0].Bytes,
+			f:    TestVectors[0].Frame,
+		},
+		{
+			desc: "FCS",
+			b:    TestVectors[len(TestVectors)-1].Bytes,
+			f:    TestVectors[len(TestVectors)-1].Frame,
+			fcs:  true,
+		},
+		{
+			desc: "FCS, invalid VLAN",
+			b: AppendFCS(append([]byte{
+				0, 1, 0, 1, 0, 1,
+				1, 0, 1, 0, 1, 0,
+				0x81, 0x00,
+				0x0f, 0xff,
+				0x08, 0x00,
+			}, make([]byte, 46)...)),
+			err: ErrInvalidVLAN,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f, fcs, err := UnmarshalAuto(tt.b)
+			if want, got := tt.err, err; want != got {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.fcs, fcs; want != got {
+				t.Fatalf("[%02d] test %q, unexpected FCS detection: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.f, f; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected Frame:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameEqual(t *testing.T) {
+	base := func() *Frame {
+		return &Frame{
+			Destination: Broadcast,
+			Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+			VLAN:        []*VLAN{{ID: 10}},
+			EtherType:   EtherTypeARP,
+			Payload:     []byte{0x01, 0x02},
+		}
+	}
+
+	var tests = []struct {
+		desc   string
+		modify func(f *Frame)
+		equal  bool
+		ignPad bool
+	}{
+		{
+			desc:   "identical",
+			modify: func(f *Frame) {},
+			equal:  true,
+			ignPad: true,
+		},
+		{
+			desc: "metadata differs",
+			modify: func(f *Frame) {
+				f.PadByte = 0xff
+				f.Timestamp = time.Unix(1, 0)
+			},
+			equal:  true,
+			ignPad: true,
+		},
+		{
+			desc: "destination differs",
+			modify: func(f *Frame) {
+				f.Destination = net.HardwareAddr{0, 1, 0, 1, 0, 1}
+			},
+		},
+		{
+			desc: "source differs",
+			modify: func(f *Frame) {
+				f.Source = nil
+			},
+		},
+		{
+			desc: "VLAN differs",
+			modify: func(f *Frame) {
+				f.VLAN[0].Priority = PriorityVoice
+			},
+		},
+		{
+			desc: "VLAN count differs",
+			modify: func(f *Frame) {
+				f.VLAN = nil
+			},
+		},
+		{
+			desc: "EtherType differs",
+			modify: func(f *Frame) {
+				f.EtherType = EtherTypeIPv4
+			},
+		},
+		{
+			desc: "zero padding",
+			modify: func(f *Frame) {
+				f.Payload = append(f.Payload, make([]byte, 44)...)
+			},
+			ignPad: true,
+		},
+		{
+			desc: "non-zero padding",
+			modify: func(f *Frame) {
+				f.Payload = append(f.Payload, 0x00, 0xff)
+			},
+		},
+		{
+			desc: "payload differs",
+			modify: func(f *Frame) {
+				f.Payload = []byte{0x01, 0x03}
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f, o := base(), base()
+			tt.modify(o)
+
+			if want, got := tt.equal, f.Equal(o); want != got {
+				t.Fatalf("[%02d] test %q, unexpected Equal: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			// Each comparison must be symmetric.
+			if want, got := tt.ignPad, f.EqualIgnorePadding(o); want != got {
+				t.Fatalf("[%02d] test %q, unexpected EqualIgnorePadding: %v != %v",
+					i, tt.desc, want, got)
+			}
+			if want, got := tt.ignPad, o.EqualIgnorePadding(f); want != got {
+				t.Fatalf("[%02d] test %q, unexpected reversed EqualIgnorePadding: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameEqualIgnorePaddingRoundTrip(t *testing.T) {
+	f := &Frame{
+		Destination: Broadcast,
+		Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+		EtherType:   EtherTypeARP,
+		Payload:     []byte{0x01, 0x02},
+	}
+
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+
+	f2 := new(Frame)
+	if err := f2.UnmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal Frame: %v", err)
+	}
+
+	if f.Equal(f2) {
+		t.Fatal("expected padded Frame to not be equal")
+	}
+	if !f.EqualIgnorePadding(f2) {
+		t.Fatal("expected padded Frame to be equal ignoring padding")
+	}
+}
+
+func TestUnmarshalBinaryAt(t *testing.T) {
+	// An outer header, followed by an embedded Frame.
+	b := append([]byte{0xaa, 0xbb, 0xcc, 0xdd}, TestVectors[2].Bytes...)
+
+	var tests = []struct {
+		desc   string
+		offset int
+		f      *Frame
+		err    error
+	}{
+		{
+			desc:   "negative offset",
+			offset: -1,
+			err:    ErrInvalidOffset,
+		},
+		{
+			desc:   "offset past end",
+			offset: len(b) + 1,
+			err:    ErrInvalidOffset,
+		},
+		{
+			desc:   "offset at end",
+			offset: len(b),
+			err:    io.ErrUnexpectedEOF,
+		},
+		{
+			desc:   "OK",
+			offset: 4,
+			f:      TestVectors[2].Frame,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f, err := UnmarshalBinaryAt(b, tt.offset)
+			if want, got := tt.err, err; !errors.Is(got, want) {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.f, f; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected Frame:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFramePayloadCRC32(t *testing.T) {
+	var tests = []struct {
+		desc string
+		p    []byte
+		c    uint32
+	}{
+		{
+			desc: "empty",
+		},
+		{
+			desc: "check value",
+			p:    []byte("123456789"),
+			c:    0xcbf43926,
+		},
+		{
+			desc: "single byte",
+			p:    []byte{0x00},
+			c:    0xd202ef8d,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			// Padding and the header must not affect the checksum.
+			f := &Frame{
+				Destination: Broadcast,
+				Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+				EtherType:   EtherTypeIPv4,
+				Payload:     tt.p,
+				PadByte:     0xff,
+			}
+
+			if want, got := tt.c, f.PayloadCRC32(); want != got {
+				t.Fatalf("[%02d] test %q, unexpected checksum: %#08x != %#08x",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFramePadTo(t *testing.T) {
+	var tests = []struct {
+		desc    string
+		vlans   int
+		payload int
+		trailer int
+		noPad   bool
+		total   int
+		msg     string
+	}{
+		{
+			desc:    "128 bytes",
+			payload: 2,
+			total:   128,
+		},
+		{
+			desc:    "128 bytes, VLAN",
+			vlans:   1,
+			payload: 2,
+			total:   128,
+		},
+		{
+			desc:    "128 bytes, 2 VLANs",
+			vlans:   2,
+			payload: 2,
+			total:   128,
+		},
+		{
+			desc:    "128 bytes, VLAN and trailer",
+			vlans:   1,
+			payload: 2,
+			trailer: 4,
+			total:   128,
+		},
+		{
+			desc:    "minimum size",
+			payload: 2,
+			total:   60,
+		},
+		{
+			desc:    "already exact",
+			payload: 100,
+			total:   114,
+		},
+		{
+			desc:    "below minimum size, NoPad",
+			payload: 2,
+			noPad:   true,
+			total:   20,
+		},
+		{
+			desc:    "below minimum size",
+			payload: 2,
+			total:   59,
+			msg:     "frame too large: 60 bytes exceeds requested length 59 by 1 bytes",
+		},
+		{
+			desc:    "too large, VLAN",
+			vlans:   1,
+			payload: 120,
+			total:   128,
+			msg:     "frame too large: 138 bytes exceeds requested length 128 by 10 bytes",
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			payload := bytes.Repeat([]byte{0xaa}, tt.payload)
+			f := &Frame{
+				Destination: Broadcast,
+				Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+				EtherType:   EtherTypeIPv4,
+				Payload:     payload,
+				PadByte:     0xa5,
+				NoPad:       tt.noPad,
+			}
+			for j := 0; j < tt.vlans; j++ {
+				f.VLAN = append(f.VLAN, &VLAN{ID: 10})
+			}
+			if tt.trailer > 0 {
+				f.Trailer = bytes.Repeat([]byte{0xbb}, tt.trailer)
+			}
+
+			err := f.PadTo(tt.total)
+			if err != nil {
+				if !errors.Is(err, ErrFrameTooLarge) {
+					t.Fatalf("[%02d] test %q, unexpected error: %v",
+						i, tt.desc, err)
+				}
+
+				if want, got := tt.msg, err.Error(); want != got {
+					t.Fatalf("[%02d] test %q, unexpected error message: %q != %q",
+						i, tt.desc, want, got)
+				}
+
+				if want, got := payload, f.Payload; !bytes.Equal(want, got) {
+					t.Fatalf("[%02d] test %q, Frame modified on error", i, tt.desc)
+				}
+				return
+			}
+			if tt.msg != "" {
+				t.Fatalf("[%02d] test %q, expected error %q", i, tt.desc, tt.msg)
+			}
+
+			b, err := f.MarshalBinary()
+			if err != nil {
+				t.Fatalf("[%02d] test %q, failed to marshal Frame: %v",
+					i, tt.desc, err)
+			}
+
+			if want, got := tt.total, len(b); want != got {
+				t.Fatalf("[%02d] test %q, unexpected Frame length: %v != %v",
+					i, tt.desc, want, got)
+			}
+			if want, got := tt.total, f.Length(); want != got {
+				t.Fatalf("[%02d] test %q, unexpected Length: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			// The payload is extended with zero bytes, not PadByte.
+			p := f.Payload[tt.payload:]
+			if want, got := make([]byte, len(p)), p; !bytes.Equal(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected padding: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package ethernet
+
+// GenerateFrames creates count deep copies of template, using Frame.Clone,
+// and calls mutate with the index and Frame of each copy, in order, so that
+// it may be modified, such as by incrementing a sequence number in its
+// payload. Because each copy is independent, mutate may modify any field of
+// a Frame without affecting template or the other copies.
+//
+// If mutate is nil, the copies are returned unmodified. If count is less than
+// or equal to 0, GenerateFrames returns nil.
+func GenerateFrames(template *Frame, count int, mutate func(i int, f *Frame)) []*Frame {
+	if count <= 0 {
+		return nil
+	}
+
+	fs := make([]*Frame, count)
+	for i := range fs {
+		f := template.Clone()
+		if mutate != nil {
+			mutate(i, f)
+		}
+
+		fs[i] = f
+	}
+
+	return fs
+}
@@ -0,0 +1,51 @@
+package ethernet
+
+import (
+	"encoding/binary"
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestGenerateFrames(t *testing.T) {
+	template := &Frame{
+		Destination: Broadcast,
+		Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+		VLAN:        []*VLAN{{ID: 10}},
+		EtherType:   0x88b5,
+		Payload:     make([]byte, 46),
+	}
+	orig := template.Clone()
+
+	if fs := GenerateFrames(template, 0, nil); fs != nil {
+		t.Fatalf("expected no Frames, but got: %v", fs)
+	}
+
+	fs := GenerateFrames(template, 3, nil)
+	for i, f := range fs {
+		if !reflect.DeepEqual(template, f) {
+			t.Fatalf("[%02d] unexpected Frame:\n- want: %v\n- got: %v",
+				i, template, f)
+		}
+	}
+
+	// Write a sequence number into each payload, and assign each Frame its
+	// own VLAN ID.
+	fs = GenerateFrames(template, 3, func(i int, f *Frame) {
+		binary.BigEndian.PutUint32(f.Payload[0:4], uint32(i))
+		f.VLAN[0].ID = uint16(100 + i)
+	})
+
+	for i, f := range fs {
+		if want, got := uint32(i), binary.BigEndian.Uint32(f.Payload[0:4]); want != got {
+			t.Fatalf("[%02d] unexpected sequence number: %v != %v", i, want, got)
+		}
+		if want, got := uint16(100+i), f.VLAN[0].ID; want != got {
+			t.Fatalf("[%02d] unexpected VLAN ID: %v != %v", i, want, got)
+		}
+	}
+
+	if !reflect.DeepEqual(orig, template) {
+		t.Fatalf("template Frame was modified:\n- want: %v\n- got: %v", orig, template)
+	}
+}
@@ -0,0 +1,15 @@
+module github.com/caser789/ethernet/gopacketlayer
+
+go 1.14
+
+require (
+	github.com/caser789/ethernet v0.0.0
+	github.com/google/gopacket v1.1.19
+)
+
+// gopacketlayer is developed alongside package ethernet, and always builds
+// against the parent directory of this repository. The placeholder version
+// required above is never fetched. Replace it with a tagged release of
+// package ethernet before gopacketlayer is published, since Go ignores this
+// directive when gopacketlayer is a dependency of another module.
+replace github.com/caser789/ethernet => ../
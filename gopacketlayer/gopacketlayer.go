@@ -0,0 +1,137 @@
+// Package gopacketlayer adapts ethernet.Frame for use with the
+// github.com/google/gopacket package.
+//
+// gopacketlayer is a separate module, so that package ethernet does not
+// depend on gopacket.
+package gopacketlayer
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/caser789/ethernet"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// ErrNotEthernet is returned by FromPacket when a gopacket.Packet does not
+// begin with an Ethernet link layer.
+var ErrNotEthernet = errors.New("packet does not begin with an Ethernet layer")
+
+// Compile-time assertion that Frame implements gopacket.SerializableLayer.
+var _ gopacket.SerializableLayer = &Frame{}
+
+// A Frame wraps an ethernet.Frame so that it implements
+// gopacket.SerializableLayer, and may be passed to gopacket.SerializeLayers
+// along with any other layers.
+type Frame struct {
+	*ethernet.Frame
+}
+
+// LayerType implements gopacket.SerializableLayer, and always returns
+// layers.LayerTypeEthernet.
+func (f *Frame) LayerType() gopacket.LayerType {
+	return layers.LayerTypeEthernet
+}
+
+// SerializeTo implements gopacket.SerializableLayer. The Frame's header and
+// Payload are prepended to b, so that any layers which have already been
+// serialized to b follow the Frame's Payload. The Frame's Trailer, if any,
+// is appended to b.
+//
+// If opts.FixLengths is set, the payload, including any layers already
+// serialized to b, is padded to the minimum size using the Frame's PadByte,
+// as done by ethernet.Frame.MarshalBinary. If opts.ComputeChecksums is set,
+// a frame check sequence is appended to b, as done by
+// ethernet.Frame.MarshalFCS.
+//
+// Every byte already in b is treated as an upper layer which follows the
+// Frame's Payload, so b must be cleared before the upper layers are
+// serialized, as gopacket.SerializeLayers does. The frame check sequence
+// covers only this Frame and its upper layers, so the Frame need not be the
+// outermost layer: a layer which encapsulates it, such as another Frame, may
+// be serialized to b afterwards.
+//
+// SerializeTo returns the same errors as ethernet.Frame.MarshalHeader.
+func (f *Frame) SerializeTo(b gopacket.SerializeBuffer, opts gopacket.SerializeOptions) error {
+	hdr, err := f.MarshalHeader()
+	if err != nil {
+		return err
+	}
+
+	pl := len(f.Payload) + len(b.Bytes())
+
+	buf, err := b.PrependBytes(len(hdr) + len(f.Payload))
+	if err != nil {
+		return err
+	}
+	n := copy(buf, hdr)
+	copy(buf[n:], f.Payload)
+
+	if opts.FixLengths {
+		// The minimum payload size depends only on the Frame's header and
+		// MinSize, so measure the length of an empty copy of the Frame.
+		c := *f.Frame
+		c.Payload = nil
+		c.Trailer = nil
+
+		if pad := c.Length() - c.HeaderOverhead() - pl; pad > 0 {
+			buf, err := b.AppendBytes(pad)
+			if err != nil {
+				return err
+			}
+			for i := range buf {
+				buf[i] = f.PadByte
+			}
+		}
+	}
+
+	if len(f.Trailer) > 0 {
+		buf, err := b.AppendBytes(len(f.Trailer))
+		if err != nil {
+			return err
+		}
+		copy(buf, f.Trailer)
+	}
+
+	if opts.ComputeChecksums {
+		// b now holds exactly this Frame, because any layer which
+		// encapsulates it has not yet been serialized.
+		body := b.Bytes()
+		if f.TrailerExcludedFromFCS {
+			body = body[:len(body)-len(f.Trailer)]
+		}
+
+		fcs := ethernet.FCS(body)
+		buf, err := b.AppendBytes(4)
+		if err != nil {
+			return err
+		}
+		binary.BigEndian.PutUint32(buf, fcs)
+	}
+
+	return nil
+}
+
+// FromPacket unmarshals the binary form of a gopacket.Packet which begins
+// with an Ethernet link layer into a new ethernet.Frame. The Frame's
+// Payload contains all of the Packet's data which follows the Ethernet
+// header, including any padding. The Packet must not contain a frame check
+// sequence.
+//
+// If the Packet does not begin with an Ethernet layer, ErrNotEthernet is
+// returned. Otherwise, FromPacket returns the same errors as
+// ethernet.Frame.UnmarshalBinary.
+func FromPacket(p gopacket.Packet) (*ethernet.Frame, error) {
+	ls := p.Layers()
+	if len(ls) == 0 || ls[0].LayerType() != layers.LayerTypeEthernet {
+		return nil, ErrNotEthernet
+	}
+
+	f := new(ethernet.Frame)
+	if err := f.UnmarshalBinary(p.Data()); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
@@ -0,0 +1,239 @@
+package gopacketlayer
+
+import (
+	"bytes"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/caser789/ethernet"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestFrameSerializeTo(t *testing.T) {
+	hdr := []byte{
+		0, 1, 0, 1, 0, 1,
+		1, 0, 1, 0, 1, 0,
+		0x88, 0xb5,
+	}
+
+	var tests = []struct {
+		desc  string
+		f     *ethernet.Frame
+		upper []byte
+		opts  gopacket.SerializeOptions
+		b     []byte
+		err   error
+	}{
+		{
+			desc: "VLAN ID too large",
+			f: &ethernet.Frame{
+				VLAN: []*ethernet.VLAN{{
+					ID: ethernet.VLANMax,
+				}},
+			},
+			err: ethernet.ErrInvalidVLAN,
+		},
+		{
+			desc: "payload only, no options",
+			f: &ethernet.Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				EtherType:   ethernet.EtherTypeLocalExperimental1,
+				Payload:     []byte{1, 2},
+			},
+			b: append(append([]byte(nil), hdr...), 1, 2),
+		},
+		{
+			desc: "upper layer follows payload",
+			f: &ethernet.Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				EtherType:   ethernet.EtherTypeLocalExperimental1,
+				Payload:     []byte{1, 2},
+			},
+			upper: []byte{3, 4},
+			b:     append(append([]byte(nil), hdr...), 1, 2, 3, 4),
+		},
+		{
+			desc: "FixLengths pads with PadByte",
+			f: &ethernet.Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				EtherType:   ethernet.EtherTypeLocalExperimental1,
+				Payload:     []byte{1, 2},
+				PadByte:     0xff,
+			},
+			upper: []byte{3, 4},
+			opts:  gopacket.SerializeOptions{FixLengths: true},
+			b: append(append(append([]byte(nil), hdr...), 1, 2, 3, 4),
+				bytes.Repeat([]byte{0xff}, ethernet.MinPayload-4)...),
+		},
+		{
+			desc: "trailer follows payload",
+			f: &ethernet.Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				EtherType:   ethernet.EtherTypeLocalExperimental1,
+				Payload:     []byte{1, 2},
+				Trailer:     []byte{0xaa},
+			},
+			upper: []byte{3},
+			b:     append(append([]byte(nil), hdr...), 1, 2, 3, 0xaa),
+		},
+	}
+
+	for i, tt := range tests {
+		buf := gopacket.NewSerializeBuffer()
+		ls := []gopacket.SerializableLayer{&Frame{tt.f}}
+		if tt.upper != nil {
+			ls = append(ls, gopacket.Payload(tt.upper))
+		}
+
+		err := gopacket.SerializeLayers(buf, tt.opts, ls...)
+		if want, got := tt.err, err; want != got {
+			t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+				i, tt.desc, want, got)
+		}
+		if err != nil {
+			continue
+		}
+
+		if want, got := tt.b, buf.Bytes(); !bytes.Equal(want, got) {
+			t.Fatalf("[%02d] test %q, unexpected bytes:\n- want: %v\n-  got: %v",
+				i, tt.desc, want, got)
+		}
+	}
+}
+
+func TestFrameSerializeToMatchesMarshal(t *testing.T) {
+	f := &ethernet.Frame{
+		Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+		Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+		VLAN: []*ethernet.VLAN{{
+			Priority: ethernet.PriorityVoice,
+			ID:       10,
+		}},
+		EtherType: ethernet.EtherTypeIPv4,
+		Payload:   []byte{1, 2, 3},
+		Trailer:   []byte{0xaa, 0xbb},
+	}
+
+	want, err := f.MarshalFCS()
+	if err != nil {
+		t.Fatalf("failed to marshal frame: %v", err)
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+	if err := gopacket.SerializeLayers(buf, opts, &Frame{f}); err != nil {
+		t.Fatalf("failed to serialize frame: %v", err)
+	}
+
+	if got := buf.Bytes(); !bytes.Equal(want, got) {
+		t.Fatalf("unexpected bytes:\n- want: %v\n-  got: %v", want, got)
+	}
+
+	// The frame check sequence may exclude the trailer.
+	f.TrailerExcludedFromFCS = true
+	want, err = f.MarshalFCS()
+	if err != nil {
+		t.Fatalf("failed to marshal frame: %v", err)
+	}
+
+	if err := buf.Clear(); err != nil {
+		t.Fatalf("failed to clear buffer: %v", err)
+	}
+	if err := gopacket.SerializeLayers(buf, opts, &Frame{f}); err != nil {
+		t.Fatalf("failed to serialize frame: %v", err)
+	}
+
+	if got := buf.Bytes(); !bytes.Equal(want, got) {
+		t.Fatalf("unexpected bytes with trailer excluded:\n- want: %v\n-  got: %v", want, got)
+	}
+}
+
+func TestFrameSerializeToEncapsulated(t *testing.T) {
+	inner := &ethernet.Frame{
+		Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+		Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+		EtherType:   ethernet.EtherTypeIPv4,
+		Payload:     bytes.Repeat([]byte{1}, ethernet.MinPayload),
+		Trailer:     []byte{0xaa, 0xbb},
+	}
+	outer := &ethernet.Frame{
+		Destination: net.HardwareAddr{2, 3, 2, 3, 2, 3},
+		Source:      net.HardwareAddr{3, 2, 3, 2, 3, 2},
+		EtherType:   ethernet.EtherTypeLocalExperimental1,
+		Payload:     []byte{0xff},
+	}
+
+	ib, err := inner.MarshalFCS()
+	if err != nil {
+		t.Fatalf("failed to marshal inner frame: %v", err)
+	}
+
+	// The outer Frame carries its own payload followed by the complete inner
+	// Frame, and its frame check sequence covers both.
+	want, err := (&ethernet.Frame{
+		Destination: outer.Destination,
+		Source:      outer.Source,
+		EtherType:   outer.EtherType,
+		Payload:     append(append([]byte(nil), outer.Payload...), ib...),
+	}).MarshalFCS()
+	if err != nil {
+		t.Fatalf("failed to marshal outer frame: %v", err)
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+	if err := gopacket.SerializeLayers(buf, opts, &Frame{inner}); err != nil {
+		t.Fatalf("failed to serialize inner frame: %v", err)
+	}
+	if err := (&Frame{outer}).SerializeTo(buf, opts); err != nil {
+		t.Fatalf("failed to serialize outer frame: %v", err)
+	}
+
+	if got := buf.Bytes(); !bytes.Equal(want, got) {
+		t.Fatalf("unexpected bytes:\n- want: %v\n-  got: %v", want, got)
+	}
+}
+
+func TestFromPacket(t *testing.T) {
+	f := &ethernet.Frame{
+		Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+		Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+		VLAN: []*ethernet.VLAN{{
+			ID: 10,
+		}},
+		EtherType: ethernet.EtherTypeLocalExperimental1,
+		Payload:   bytes.Repeat([]byte{1}, ethernet.MinPayload),
+	}
+
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal frame: %v", err)
+	}
+
+	p := gopacket.NewPacket(b, layers.LayerTypeEthernet, gopacket.Default)
+	got, err := FromPacket(p)
+	if err != nil {
+		t.Fatalf("failed to convert packet: %v", err)
+	}
+
+	if !reflect.DeepEqual(f, got) {
+		t.Fatalf("unexpected frame:\n- want: %#v\n-  got: %#v", f, got)
+	}
+
+	p = gopacket.NewPacket(b, layers.LayerTypeIPv4, gopacket.Default)
+	if _, err := FromPacket(p); err != ErrNotEthernet {
+		t.Fatalf("unexpected error: %v != %v", ErrNotEthernet, err)
+	}
+}
@@ -0,0 +1,256 @@
+package ethernet
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+)
+
+// IndexKeySize is the size in bytes of the key produced by
+// Frame.MarshalIndexKey.
+const IndexKeySize = 18
+
+// A FrameHeader is the header of a Frame, without its payload. A FrameHeader
+// is useful for inspecting or indexing Frames without copying their payloads.
+type FrameHeader struct {
+	// Destination specifies the destination hardware address of a Frame.
+	Destination net.HardwareAddr
+
+	// Source specifies the source hardware address of a Frame.
+	Source net.HardwareAddr
+
+	// VLAN specifies zero or more 802.1Q VLAN tags of a Frame.
+	VLAN []*VLAN
+
+	// EtherType identifies the upper layer protocol encapsulated in a Frame.
+	EtherType EtherType
+}
+
+// ParseHeader parses the header of a Frame from a byte slice, and returns
+// the FrameHeader and the offset of the Frame's payload in b.
+//
+// ParseHeader does not copy the hardware addresses: the Destination and
+// Source fields of the FrameHeader point directly into b. The UndefinedRange
+// policy is not consulted.
+//
+// If the byte slice does not contain enough data to parse a complete header,
+// io.ErrUnexpectedEOF is returned. If one or more VLANs are invalid,
+// ErrInvalidVLAN is returned.
+func ParseHeader(b []byte) (FrameHeader, int, error) {
+	return parseHeader(b, nil, headerOptions{})
+}
+
+// headerOptions specifies options for parseHeader.
+type headerOptions struct {
+	// reuse specifies that VLAN structs referenced by the spare capacity of
+	// the VLAN slice are overwritten rather than allocated.
+	reuse bool
+
+	// maxTags, if greater than 0, specifies the maximum number of VLAN tags
+	// to parse.
+	maxTags int
+}
+
+// parseHeader implements ParseHeader, appending any VLAN tags to vlans to
+// form the VLAN field of the FrameHeader, according to opts.
+//
+// If more than opts.maxTags VLAN tags are present, parseHeader returns the
+// header parsed so far, with the tag protocol identifier of the next tag as
+// its EtherType and the offset immediately following it, along with
+// ErrTooManyVLANs.
+func parseHeader(b []byte, vlans []*VLAN, opts headerOptions) (FrameHeader, int, error) {
+	// Verify that both hardware addresses and a single EtherType are present
+	if len(b) < 14 {
+		return FrameHeader{}, 0, io.ErrUnexpectedEOF
+	}
+
+	// Track offset in packet for reading data
+	n := 14
+
+	// Continue looping and parsing VLAN tags until no more VLAN tag protocol
+	// identifiers are detected
+	et := EtherType(binary.BigEndian.Uint16(b[n-2 : n]))
+	for tags := 0; et.isVLANTPID(); n += 4 {
+		if opts.maxTags > 0 && tags == opts.maxTags {
+			return FrameHeader{
+				Destination: b[0:6:6],
+				Source:      b[6:12:12],
+				VLAN:        vlans,
+				EtherType:   et,
+			}, n, ErrTooManyVLANs
+		}
+		tags++
+
+		// 4 or more bytes must remain for valid VLAN tag and EtherType
+		if len(b[n:]) < 4 {
+			return FrameHeader{}, 0, io.ErrUnexpectedEOF
+		}
+
+		// Body of VLAN tag is 2 bytes in length;
+		var vlan *VLAN
+		if opts.reuse && len(vlans) < cap(vlans) {
+			vlan = vlans[:len(vlans)+1][len(vlans)]
+		}
+		if vlan == nil {
+			vlan = new(VLAN)
+		}
+
+		if err := vlan.UnmarshalBinary(b[n : n+2]); err != nil {
+			return FrameHeader{}, 0, err
+		}
+		vlan.setTPID(et)
+		vlans = append(vlans, vlan)
+
+		// Parse next tag to determine if it is another VLAN, or if not,
+		// break the loop
+		et = EtherType(binary.BigEndian.Uint16(b[n+2 : n+4]))
+	}
+
+	return FrameHeader{
+		Destination: b[0:6:6],
+		Source:      b[6:12:12],
+		VLAN:        vlans,
+		EtherType:   et,
+	}, n, nil
+}
+
+// checkTags verifies the VLAN tags in the header of the Frame in b, as
+// parseHeader would parse them with maxTags, without modifying any VLAN
+// structs. checkTags returns the same errors as parseHeader.
+func checkTags(b []byte, maxTags int) error {
+	if len(b) < 14 {
+		return io.ErrUnexpectedEOF
+	}
+
+	n := 14
+	et := EtherType(binary.BigEndian.Uint16(b[n-2 : n]))
+	for tags := 0; et.isVLANTPID(); n += 4 {
+		if maxTags > 0 && tags == maxTags {
+			return ErrTooManyVLANs
+		}
+		tags++
+
+		if len(b[n:]) < 4 {
+			return io.ErrUnexpectedEOF
+		}
+
+		var vlan VLAN
+		if err := vlan.UnmarshalBinary(b[n : n+2]); err != nil {
+			return err
+		}
+
+		et = EtherType(binary.BigEndian.Uint16(b[n+2 : n+4]))
+	}
+
+	return nil
+}
+
+// Classify returns the innermost EtherType of the Frame in b, and the ID of
+// its outermost VLAN tag and true if the Frame is tagged. Classify walks any
+// stacked VLAN tags in the header of b, but does not build a Frame or
+// FrameHeader, and does not allocate, so it is suitable for classifying
+// Frames in a hot receive path. The UndefinedRange policy is not consulted.
+//
+// Classify returns the same errors as ParseHeader.
+func Classify(b []byte) (et EtherType, outerVID uint16, hasVLAN bool, err error) {
+	if len(b) < 14 {
+		return 0, 0, false, io.ErrUnexpectedEOF
+	}
+
+	n := 14
+	et = EtherType(binary.BigEndian.Uint16(b[n-2 : n]))
+	for ; et.isVLANTPID(); n += 4 {
+		if len(b[n:]) < 4 {
+			return 0, 0, false, io.ErrUnexpectedEOF
+		}
+
+		id := binary.BigEndian.Uint16(b[n:n+2]) & 0x0fff
+		if id >= VLANMax {
+			return 0, 0, false, ErrInvalidVLAN
+		}
+		if !hasVLAN {
+			outerVID = id
+			hasVLAN = true
+		}
+
+		et = EtherType(binary.BigEndian.Uint16(b[n+2 : n+4]))
+	}
+
+	return et, outerVID, hasVLAN, nil
+}
+
+// RequiredBytes reports the total number of bytes needed to parse the
+// complete header of the Frame beginning with prefix, including any VLAN
+// tags and the EtherType. The payload is not included, because its length
+// is unbounded. RequiredBytes is useful for streaming readers which must
+// decide whether enough of a Frame is available to call ParseHeader or an
+// unmarshal method.
+//
+// If prefix does not yet contain the entire header, the result is the number
+// of bytes needed to read the next unknown part of it, and may increase once
+// those bytes are available: for example, a prefix of fewer than 14 bytes
+// requires 14 bytes, and a prefix which ends within a VLAN tag requires the
+// rest of that tag and the field which follows it. A caller should read
+// until len(prefix) is at least the result.
+//
+// If one or more VLANs in prefix are invalid, ErrInvalidVLAN is returned.
+func RequiredBytes(prefix []byte) (int, error) {
+	n := 14
+	if len(prefix) < n {
+		return n, nil
+	}
+
+	for et := EtherType(binary.BigEndian.Uint16(prefix[n-2 : n])); et.isVLANTPID(); n += 4 {
+		// A VLAN tag and the field which follows it.
+		if len(prefix[n:]) < 4 {
+			return n + 4, nil
+		}
+
+		if binary.BigEndian.Uint16(prefix[n:n+2])&0x0fff >= VLANMax {
+			return 0, ErrInvalidVLAN
+		}
+
+		et = EtherType(binary.BigEndian.Uint16(prefix[n+2 : n+4]))
+	}
+
+	return n, nil
+}
+
+// MarshalIndexKey produces a fixed-size, comparable encoding of a Frame's
+// header, which is suitable for use as a map key, or for sorting and indexing
+// Frames, such as in an on-disk index of a capture. It is not the binary form
+// of a Frame, and cannot be unmarshaled.
+//
+// The layout of the key is:
+//   - bytes 0-5: the destination hardware address
+//   - bytes 6-11: the source hardware address
+//   - bytes 12-13: the EtherType
+//   - bytes 14-15: the tag protocol identifier of the outermost VLAN tag
+//   - bytes 16-17: the tag control information (priority, drop eligible,
+//     and ID) of the outermost VLAN tag
+//
+// All multi-byte values are big-endian, so keys sort by destination, source,
+// and then EtherType. The VLAN bytes are zero if the Frame is untagged.
+// Hardware addresses which are not 6 bytes in length are truncated or
+// zero-filled. The key is lossy: inner VLAN tags and the payload are not
+// included.
+func (f *Frame) MarshalIndexKey() [IndexKeySize]byte {
+	var k [IndexKeySize]byte
+	copy(k[0:6], f.Destination)
+	copy(k[6:12], f.Source)
+	binary.BigEndian.PutUint16(k[12:14], uint16(f.EtherType))
+
+	if len(f.VLAN) > 0 {
+		v := f.VLAN[0]
+
+		tci := uint16(v.Priority)<<13 | v.ID&0x0fff
+		if v.DropEligible {
+			tci |= 0x1000
+		}
+
+		binary.BigEndian.PutUint16(k[14:16], uint16(v.tpid()))
+		binary.BigEndian.PutUint16(k[16:18], tci)
+	}
+
+	return k
+}
@@ -0,0 +1,387 @@
+package ethernet
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestParseHeader(t *testing.T) {
+	var tests = []struct {
+		desc string
+		b    []byte
+		h    FrameHeader
+		n    int
+		err  error
+	}{
+		{
+			desc: "short buffer",
+			b:    bytes.Repeat([]byte{0}, 13),
+			err:  io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "1 short VLAN",
+			b: []byte{
+				0, 0, 0, 0, 0, 0,
+				0, 0, 0, 0, 0, 0,
+				0x81, 0x00,
+				0x00,
+			},
+			err: io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "VLAN ID too large",
+			b: []byte{
+				0, 0, 0, 0, 0, 0,
+				0, 0, 0, 0, 0, 0,
+				0x81, 0x00,
+				0xff, 0xff,
+				0x00, 0x00,
+			},
+			err: ErrInvalidVLAN,
+		},
+		{
+			desc: "IPv4, no VLANs",
+			b: []byte{
+				0, 1, 0, 1, 0, 1,
+				1, 0, 1, 0, 1, 0,
+				0x08, 0x00,
+				0xde, 0xad,
+			},
+			h: FrameHeader{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				EtherType:   EtherTypeIPv4,
+			},
+			n: 14,
+		},
+		{
+			desc: "IPv6, 1 VLAN: PRI 1, ID 101",
+			b: []byte{
+				1, 0, 1, 0, 1, 0,
+				0, 1, 0, 1, 0, 1,
+				0x81, 0x00,
+				0x20, 0x65,
+				0x86, 0xDD,
+				0xde, 0xad,
+			},
+			h: FrameHeader{
+				Destination: net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				Source:      net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				VLAN: []*VLAN{{
+					Priority: 1,
+					ID:       101,
+				}},
+				EtherType: EtherTypeIPv6,
+			},
+			n: 18,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			h, n, err := ParseHeader(tt.b)
+			if err != nil {
+				if want, got := tt.err, err; want != got {
+					t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+						i, tt.desc, want, got)
+				}
+
+				return
+			}
+
+			if want, got := tt.h, h; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected FrameHeader:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.n, n; want != got {
+				t.Fatalf("[%02d] test %q, unexpected payload offset: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	var tests = []struct {
+		desc    string
+		b       []byte
+		et      EtherType
+		vid     uint16
+		hasVLAN bool
+		err     error
+	}{
+		{
+			desc: "short buffer",
+			b:    bytes.Repeat([]byte{0}, 13),
+			err:  io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "1 short VLAN",
+			b: []byte{
+				0, 0, 0, 0, 0, 0,
+				0, 0, 0, 0, 0, 0,
+				0x81, 0x00,
+				0x00,
+			},
+			err: io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "VLAN ID too large",
+			b: []byte{
+				0, 0, 0, 0, 0, 0,
+				0, 0, 0, 0, 0, 0,
+				0x81, 0x00,
+				0xff, 0xff,
+				0x00, 0x00,
+			},
+			err: ErrInvalidVLAN,
+		},
+		{
+			desc: "IPv4, no VLANs",
+			b: []byte{
+				0, 1, 0, 1, 0, 1,
+				1, 0, 1, 0, 1, 0,
+				0x08, 0x00,
+				0xde, 0xad,
+			},
+			et: EtherTypeIPv4,
+		},
+		{
+			desc: "IPv6, 1 VLAN: PRI 1, ID 101",
+			b: []byte{
+				1, 0, 1, 0, 1, 0,
+				0, 1, 0, 1, 0, 1,
+				0x81, 0x00,
+				0x20, 0x65,
+				0x86, 0xDD,
+				0xde, 0xad,
+			},
+			et:      EtherTypeIPv6,
+			vid:     101,
+			hasVLAN: true,
+		},
+		{
+			desc: "ARP, 2 VLANs: ID 10, ID 20",
+			b: []byte{
+				1, 0, 1, 0, 1, 0,
+				0, 1, 0, 1, 0, 1,
+				0x88, 0xa8,
+				0x00, 0x0a,
+				0x81, 0x00,
+				0x00, 0x14,
+				0x08, 0x06,
+			},
+			et:      EtherTypeARP,
+			vid:     10,
+			hasVLAN: true,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			et, vid, hasVLAN, err := Classify(tt.b)
+			if want, got := tt.err, err; want != got {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.et, et; want != got {
+				t.Fatalf("[%02d] test %q, unexpected EtherType: %v != %v",
+					i, tt.desc, want, got)
+			}
+			if want, got := tt.vid, vid; want != got {
+				t.Fatalf("[%02d] test %q, unexpected outer VLAN ID: %v != %v",
+					i, tt.desc, want, got)
+			}
+			if want, got := tt.hasVLAN, hasVLAN; want != got {
+				t.Fatalf("[%02d] test %q, unexpected hasVLAN: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestClassifyNoAllocations(t *testing.T) {
+	b := TestVectors[3].Bytes
+
+	allocs := testing.AllocsPerRun(10, func() {
+		if _, _, _, err := Classify(b); err != nil {
+			panic(err)
+		}
+	})
+	if want, got := 0.0, allocs; want != got {
+		t.Fatalf("unexpected number of allocations: %v != %v", want, got)
+	}
+}
+
+func BenchmarkClassify(b *testing.B) {
+	buf := TestVectors[3].Bytes
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, _, err := Classify(buf); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestRequiredBytes(t *testing.T) {
+	qinq := []byte{
+		0, 1, 0, 1, 0, 1,
+		1, 0, 1, 0, 1, 0,
+		0x88, 0xa8,
+		0x00, 0x0a,
+		0x81, 0x00,
+		0x00, 0x14,
+		0x08, 0x06,
+		0xde, 0xad,
+	}
+
+	var tests = []struct {
+		desc string
+		b    []byte
+		n    int
+		err  error
+	}{
+		{
+			desc: "empty",
+			n:    14,
+		},
+		{
+			desc: "partial addresses",
+			b:    qinq[:8],
+			n:    14,
+		},
+		{
+			desc: "outer TPID",
+			b:    qinq[:14],
+			n:    18,
+		},
+		{
+			desc: "partial outer tag",
+			b:    qinq[:15],
+			n:    18,
+		},
+		{
+			desc: "inner TPID",
+			b:    qinq[:18],
+			n:    22,
+		},
+		{
+			desc: "complete header",
+			b:    qinq[:22],
+			n:    22,
+		},
+		{
+			desc: "header and payload",
+			b:    qinq,
+			n:    22,
+		},
+		{
+			desc: "untagged",
+			b: []byte{
+				0, 1, 0, 1, 0, 1,
+				1, 0, 1, 0, 1, 0,
+				0x08, 0x00,
+			},
+			n: 14,
+		},
+		{
+			desc: "VLAN ID too large",
+			b: []byte{
+				0, 0, 0, 0, 0, 0,
+				0, 0, 0, 0, 0, 0,
+				0x81, 0x00,
+				0xff, 0xff,
+				0x00, 0x00,
+			},
+			err: ErrInvalidVLAN,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			n, err := RequiredBytes(tt.b)
+			if want, got := tt.err, err; want != got {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.n, n; want != got {
+				t.Fatalf("[%02d] test %q, unexpected number of bytes: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			// Once enough bytes are available, the header must parse.
+			if err == nil && len(tt.b) >= n {
+				if _, off, err := ParseHeader(tt.b); err != nil || off != n {
+					t.Fatalf("[%02d] test %q, failed to parse header: %d, %v",
+						i, tt.desc, off, err)
+				}
+			}
+		})
+	}
+}
+
+func TestFrameMarshalIndexKey(t *testing.T) {
+	var tests = []struct {
+		desc string
+		f    *Frame
+		k    [IndexKeySize]byte
+	}{
+		{
+			desc: "empty",
+			f:    &Frame{},
+		},
+		{
+			desc: "IPv4, untagged",
+			f:    TestVectors[0].Frame,
+			k: [IndexKeySize]byte{
+				0x00, 0x16, 0x3e, 0x11, 0x22, 0x33,
+				0x00, 0x16, 0x3e, 0x44, 0x55, 0x66,
+				0x08, 0x00,
+			},
+		},
+		{
+			desc: "IPv4, Q-in-Q, inner tag ignored",
+			f:    TestVectors[3].Frame,
+			k: [IndexKeySize]byte{
+				0x00, 0x16, 0x3e, 0x11, 0x22, 0x33,
+				0x00, 0x16, 0x3e, 0x44, 0x55, 0x66,
+				0x08, 0x00,
+				0x88, 0xa8,
+				0x10, 0x0a,
+			},
+		},
+		{
+			desc: "legacy TPID",
+			f: &Frame{
+				VLAN: []*VLAN{{
+					Priority: PriorityVoice,
+					ID:       200,
+					TPID:     EtherTypeQinQ,
+				}},
+				EtherType: EtherTypeIPv6,
+			},
+			k: [IndexKeySize]byte{
+				12: 0x86, 13: 0xdd,
+				14: 0x91, 15: 0x00,
+				16: 0xa0, 17: 0xc8,
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if want, got := tt.k, tt.f.MarshalIndexKey(); want != got {
+				t.Fatalf("[%02d] test %q, unexpected index key:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
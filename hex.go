@@ -0,0 +1,91 @@
+package ethernet
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// UnmarshalHex decodes a hexadecimal string, such as a hex dump pasted from
+// a packet capture tool, and unmarshals it into a Frame. Whitespace and colon
+// separators are ignored, so "00 16 3e" and "00:16:3e" are equivalent to
+// "00163e".
+//
+// If s contains an odd number of hexadecimal digits or an invalid character,
+// an error wrapping hex.ErrLength or a hex.InvalidByteError is returned.
+// UnmarshalHex otherwise returns the same errors as Frame.UnmarshalBinary.
+func UnmarshalHex(s string) (*Frame, error) {
+	s = strings.Map(func(r rune) rune {
+		if r == ':' || unicode.IsSpace(r) {
+			return -1
+		}
+
+		return r
+	}, s)
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hexadecimal frame: %w", err)
+	}
+
+	f := new(Frame)
+	if err := f.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// MarshalHex marshals a Frame into binary form, and returns it as a string
+// of lowercase hexadecimal digits with no separators. MarshalHex returns the
+// same errors as MarshalBinary.
+func (f *Frame) MarshalHex() (string, error) {
+	b, err := f.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// HexDump marshals a Frame into binary form, and formats it as a text hex
+// dump which may be imported by Wireshark or text2pcap. If fcs is true, the
+// Frame is marshaled with a frame check sequence, as by MarshalFCS.
+//
+// Each line of the dump contains up to 16 bytes, and begins with the offset
+// of its first byte as 6 hexadecimal digits, followed by two spaces and each
+// byte as 2 lowercase hexadecimal digits separated by a single space. Each
+// line, including the last, ends with a newline. For example:
+//
+//	000000  ff ff ff ff ff ff 00 16 3e 44 55 66 08 06 00 01
+//	000010  08 00 06 04 00 01 00 16 3e 44 55 66 c0 00 02 01
+//
+// HexDump returns the same errors as MarshalBinary.
+func (f *Frame) HexDump(fcs bool) (string, error) {
+	marshal := f.MarshalBinary
+	if fcs {
+		marshal = f.MarshalFCS
+	}
+
+	b, err := marshal()
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	for off := 0; off < len(b); off += 16 {
+		end := off + 16
+		if end > len(b) {
+			end = len(b)
+		}
+
+		fmt.Fprintf(&sb, "%06x ", off)
+		for _, c := range b[off:end] {
+			fmt.Fprintf(&sb, " %02x", c)
+		}
+		sb.WriteByte('\n')
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,180 @@
+package ethernet
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnmarshalHex(t *testing.T) {
+	payload := strings.Repeat("00", 46)
+
+	var tests = []struct {
+		desc string
+		s    string
+		f    *Frame
+		err  error
+	}{
+		{
+			desc: "odd length",
+			s:    "001",
+			err:  hex.ErrLength,
+		},
+		{
+			desc: "invalid character",
+			s:    "00zz",
+			err:  hex.InvalidByteError('z'),
+		},
+		{
+			desc: "short frame",
+			s:    "00163e112233",
+			err:  io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "OK, no separators",
+			s:    "00163e112233" + "00163e445566" + "0800" + payload,
+			f: &Frame{
+				Destination: net.HardwareAddr{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33},
+				Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+				EtherType:   EtherTypeIPv4,
+				Payload:     make([]byte, 46),
+			},
+		},
+		{
+			desc: "OK, separators",
+			s:    "00:16:3e:11:22:33 00:16:3e:44:55:66\n\t08 06\n" + payload,
+			f: &Frame{
+				Destination: net.HardwareAddr{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33},
+				Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+				EtherType:   EtherTypeARP,
+				Payload:     make([]byte, 46),
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f, err := UnmarshalHex(tt.s)
+			if want, got := tt.err, err; !errors.Is(got, want) {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.f, f; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected Frame:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameMarshalHex(t *testing.T) {
+	for i, tt := range TestVectors {
+		if tt.FCS {
+			continue
+		}
+
+		t.Run(tt.Name, func(t *testing.T) {
+			s, err := tt.Frame.MarshalHex()
+			if err != nil {
+				t.Fatalf("[%02d] test %q, failed to marshal hex: %v",
+					i, tt.Name, err)
+			}
+
+			if want, got := hex.EncodeToString(tt.Bytes), s; want != got {
+				t.Fatalf("[%02d] test %q, unexpected hex:\n- want: %s\n- got: %s",
+					i, tt.Name, want, got)
+			}
+
+			f, err := UnmarshalHex(s)
+			if err != nil {
+				t.Fatalf("[%02d] test %q, failed to unmarshal hex: %v",
+					i, tt.Name, err)
+			}
+
+			if want, got := tt.Frame, f; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected Frame:\n- want: %v\n- got: %v",
+					i, tt.Name, want, got)
+			}
+		})
+	}
+
+	if _, err := (&Frame{VLAN: []*VLAN{{ID: VLANMax}}}).MarshalHex(); err != ErrInvalidVLAN {
+		t.Fatalf("unexpected error for invalid VLAN: %v", err)
+	}
+}
+
+func TestFrameHexDump(t *testing.T) {
+	f := &Frame{
+		Destination: Broadcast,
+		Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+		EtherType:   EtherTypeIPv4,
+		Payload:     vectorPayload(50),
+	}
+
+	var tests = []struct {
+		desc string
+		f    *Frame
+		fcs  bool
+		s    string
+		err  error
+	}{
+		{
+			desc: "invalid VLAN",
+			f: &Frame{
+				VLAN: []*VLAN{{ID: VLANMax}},
+			},
+			err: ErrInvalidVLAN,
+		},
+		{
+			desc: "no FCS",
+			f:    f,
+			s: "" +
+				"000000  ff ff ff ff ff ff 00 16 3e 44 55 66 08 00 00 01\n" +
+				"000010  02 03 04 05 06 07 08 09 0a 0b 0c 0d 0e 0f 10 11\n" +
+				"000020  12 13 14 15 16 17 18 19 1a 1b 1c 1d 1e 1f 20 21\n" +
+				"000030  22 23 24 25 26 27 28 29 2a 2b 2c 2d 2e 2f 30 31\n",
+		},
+		{
+			desc: "FCS",
+			f:    f,
+			fcs:  true,
+			s: "" +
+				"000000  ff ff ff ff ff ff 00 16 3e 44 55 66 08 00 00 01\n" +
+				"000010  02 03 04 05 06 07 08 09 0a 0b 0c 0d 0e 0f 10 11\n" +
+				"000020  12 13 14 15 16 17 18 19 1a 1b 1c 1d 1e 1f 20 21\n" +
+				"000030  22 23 24 25 26 27 28 29 2a 2b 2c 2d 2e 2f 30 31\n" +
+				"000040  " + fcsHex(f) + "\n",
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s, err := tt.f.HexDump(tt.fcs)
+			if want, got := tt.err, err; want != got {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.s, s; want != got {
+				t.Fatalf("[%02d] test %q, unexpected hex dump:\n- want:\n%s\n- got:\n%s",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+// fcsHex returns the frame check sequence of f, formatted as in a hex dump.
+func fcsHex(f *Frame) string {
+	b, err := f.MarshalFCS()
+	if err != nil {
+		panic(err)
+	}
+
+	return fmt.Sprintf("% x", b[len(b)-4:])
+}
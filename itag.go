@@ -0,0 +1,154 @@
+package ethernet
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+const (
+	// ISIDMax is the maximum value of a 24-bit I-TAG service instance
+	// identifier.
+	ISIDMax = 0xffffff
+)
+
+var (
+	// ErrInvalidITag is returned when an I-TAG is invalid due to one of the
+	// following reasons:
+	//   - Priority of greater than 7 is detected
+	//   - ISID of greater than 16777215 (0xffffff) is detected
+	ErrInvalidITag = errors.New("invalid I-TAG")
+)
+
+// An ITag is an IEEE 802.1ah Provider Backbone Bridging (MAC-in-MAC) service
+// instance tag (I-TAG). An ITag identifies the service instance of a customer
+// Frame encapsulated in a backbone Frame.
+type ITag struct {
+	// Priority specifies an IEEE 802.1p priority level.
+	Priority Priority
+
+	// DropEligible indicates if a Frame is eligible to be dropped in the
+	// presence of network congestion.
+	DropEligible bool
+
+	// UseCustomerAddresses indicates that the customer addresses of the
+	// encapsulated Frame should be used for processing at the egress of
+	// the backbone.
+	UseCustomerAddresses bool
+
+	// ISID specifies the 24-bit backbone service instance identifier.
+	ISID uint32
+}
+
+// MarshalBinary allocates a byte slice and marshals an ITag into binary form.
+//
+// If an ITag priority is too large (greater than 7), or its ISID is too large
+// (greater than 16777215), ErrInvalidITag is returned.
+func (t *ITag) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 4)
+	_, err := t.read(b)
+	return b, err
+}
+
+// read reads data from an ITag into b. read is used to marshal an ITag into
+// binary form, but does not allocate on its own.
+func (t *ITag) read(b []byte) (int, error) {
+	if t.Priority > PriorityNetworkControl || t.ISID > ISIDMax {
+		return 0, ErrInvalidITag
+	}
+
+	// 3 bits: priority
+	ub := uint32(t.Priority) << 29
+
+	// 1 bit: drop eligible
+	if t.DropEligible {
+		ub |= 1 << 28
+	}
+
+	// 1 bit: use customer addresses
+	if t.UseCustomerAddresses {
+		ub |= 1 << 27
+	}
+
+	// 3 bits: reserved
+	// 24 bits: ISID
+	ub |= t.ISID
+
+	binary.BigEndian.PutUint32(b, ub)
+
+	return 4, nil
+}
+
+// UnmarshalBinary unmarshals a byte slice into an ITag.
+//
+// If the byte slice does not contain exactly 4 bytes of data,
+// io.ErrUnexpectedEOF is returned. Reserved bits are ignored.
+func (t *ITag) UnmarshalBinary(b []byte) error {
+	// I-TAG control information is always 4 bytes
+	if len(b) != 4 {
+		return io.ErrUnexpectedEOF
+	}
+
+	ub := binary.BigEndian.Uint32(b)
+	t.Priority = Priority(uint8(ub >> 29))
+	t.DropEligible = ub&(1<<28) != 0
+	t.UseCustomerAddresses = ub&(1<<27) != 0
+	t.ISID = ub & ISIDMax
+
+	return nil
+}
+
+// EncapsulateITag encapsulates the customer Frame inner in a Frame, using
+// the IEEE 802.1ah I-TAG t. The Frame's hardware addresses and VLAN tags
+// are used as the backbone addresses and backbone VLAN tag, and its
+// EtherType and payload are set to the I-TAG followed by the binary form
+// of inner.
+//
+// If t is invalid, ErrInvalidITag is returned. If either Frame contains
+// invalid VLANs, ErrInvalidVLAN is returned.
+func (f *Frame) EncapsulateITag(t *ITag, inner *Frame) error {
+	if err := f.validateVLANs(); err != nil {
+		return err
+	}
+
+	b := make([]byte, 4+inner.Length())
+	if _, err := t.read(b[:4]); err != nil {
+		return err
+	}
+	if _, err := inner.read(b[4:]); err != nil {
+		return err
+	}
+
+	f.EtherType = EtherTypeITag
+	f.Payload = b
+	return nil
+}
+
+// DecapsulateITag unmarshals the I-TAG and customer Frame from the payload
+// of a Frame with EtherType EtherTypeITag, reversing the operation performed
+// by EncapsulateITag.
+//
+// If the Frame does not have EtherType EtherTypeITag, ErrNotEncapsulated is
+// returned. If the payload is too short to contain an I-TAG,
+// io.ErrUnexpectedEOF is returned. Otherwise, DecapsulateITag returns the
+// same errors as UnmarshalBinary.
+func (f *Frame) DecapsulateITag() (*ITag, *Frame, error) {
+	if f.EtherType != EtherTypeITag {
+		return nil, nil, ErrNotEncapsulated
+	}
+	if len(f.Payload) < 4 {
+		return nil, nil, io.ErrUnexpectedEOF
+	}
+
+	t := new(ITag)
+	if err := t.UnmarshalBinary(f.Payload[:4]); err != nil {
+		return nil, nil, err
+	}
+
+	inner := new(Frame)
+	if err := inner.UnmarshalBinary(f.Payload[4:]); err != nil {
+		return nil, nil, err
+	}
+
+	return t, inner, nil
+}
@@ -0,0 +1,198 @@
+package ethernet
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestITagMarshalBinary(t *testing.T) {
+	var tests = []struct {
+		desc string
+		t    *ITag
+		b    []byte
+		err  error
+	}{
+		{
+			desc: "I-TAG priority too large",
+			t: &ITag{
+				Priority: 8,
+			},
+			err: ErrInvalidITag,
+		},
+		{
+			desc: "I-TAG ISID too large",
+			t: &ITag{
+				ISID: ISIDMax + 1,
+			},
+			err: ErrInvalidITag,
+		},
+		{
+			desc: "empty I-TAG",
+			t:    &ITag{},
+			b:    []byte{0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			desc: "I-TAG: PRI 5, DROP, UCA, ISID 0x123456",
+			t: &ITag{
+				Priority:             PriorityVoice,
+				DropEligible:         true,
+				UseCustomerAddresses: true,
+				ISID:                 0x123456,
+			},
+			b: []byte{0xb8, 0x12, 0x34, 0x56},
+		},
+		{
+			desc: "I-TAG: max ISID",
+			t: &ITag{
+				ISID: ISIDMax,
+			},
+			b: []byte{0x00, 0xff, 0xff, 0xff},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			b, err := tt.t.MarshalBinary()
+			if err != nil {
+				if want, got := tt.err, err; want != got {
+					t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+						i, tt.desc, want, got)
+				}
+
+				return
+			}
+
+			if want, got := tt.b, b; !bytes.Equal(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected I-TAG bytes:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestITagUnmarshalBinary(t *testing.T) {
+	var tests = []struct {
+		desc string
+		b    []byte
+		t    *ITag
+		err  error
+	}{
+		{
+			desc: "short buffer",
+			b:    []byte{0, 0, 0},
+			err:  io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "I-TAG: PRI 5, DROP, UCA, ISID 0x123456",
+			b:    []byte{0xb8, 0x12, 0x34, 0x56},
+			t: &ITag{
+				Priority:             PriorityVoice,
+				DropEligible:         true,
+				UseCustomerAddresses: true,
+				ISID:                 0x123456,
+			},
+		},
+		{
+			desc: "I-TAG: reserved bits ignored",
+			b:    []byte{0x07, 0x00, 0x00, 0x01},
+			t: &ITag{
+				ISID: 1,
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			it := new(ITag)
+			if err := it.UnmarshalBinary(tt.b); err != nil {
+				if want, got := tt.err, err; want != got {
+					t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+						i, tt.desc, want, got)
+				}
+
+				return
+			}
+
+			if want, got := tt.t, it; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected I-TAG:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameEncapsulateITag(t *testing.T) {
+	tag := &ITag{
+		Priority: PriorityVideo,
+		ISID:     0xabcdef,
+	}
+
+	inner := &Frame{
+		Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+		Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+		EtherType:   EtherTypeIPv4,
+		Payload:     bytes.Repeat([]byte{0xff}, 50),
+	}
+
+	backbone := &Frame{
+		Destination: net.HardwareAddr{0, 2, 0, 2, 0, 2},
+		Source:      net.HardwareAddr{2, 0, 2, 0, 2, 0},
+		VLAN: []*VLAN{{
+			ID: 200,
+		}},
+	}
+
+	if err := backbone.EncapsulateITag(&ITag{ISID: ISIDMax + 1}, inner); err != ErrInvalidITag {
+		t.Fatalf("unexpected error for invalid I-TAG: %v != %v", ErrInvalidITag, err)
+	}
+
+	if err := backbone.EncapsulateITag(tag, inner); err != nil {
+		t.Fatalf("failed to encapsulate Frame: %v", err)
+	}
+
+	b, err := backbone.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal backbone Frame: %v", err)
+	}
+
+	// Backbone header, B-TAG, I-TAG, customer addresses.
+	want := []byte{
+		0, 2, 0, 2, 0, 2,
+		2, 0, 2, 0, 2, 0,
+		0x81, 0x00,
+		0x00, 0xc8,
+		0x88, 0xe7,
+		0x80, 0xab, 0xcd, 0xef,
+		0, 1, 0, 1, 0, 1,
+		1, 0, 1, 0, 1, 0,
+		0x08, 0x00,
+	}
+	if got := b[:len(want)]; !bytes.Equal(want, got) {
+		t.Fatalf("unexpected backbone Frame bytes:\n- want: %v\n- got: %v", want, got)
+	}
+
+	backbone2 := new(Frame)
+	if err := backbone2.UnmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal backbone Frame: %v", err)
+	}
+
+	gotTag, gotInner, err := backbone2.DecapsulateITag()
+	if err != nil {
+		t.Fatalf("failed to decapsulate Frame: %v", err)
+	}
+
+	if want, got := tag, gotTag; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected I-TAG:\n- want: %v\n- got: %v", want, got)
+	}
+
+	if want, got := inner, gotInner; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected inner Frame:\n- want: %v\n- got: %v", want, got)
+	}
+
+	if _, _, err := inner.DecapsulateITag(); err != ErrNotEncapsulated {
+		t.Fatalf("unexpected error for IPv4 Frame: %v != %v", ErrNotEncapsulated, err)
+	}
+}
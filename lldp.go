@@ -0,0 +1,151 @@
+package ethernet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// IEEE 802.1AB Link Layer Discovery Protocol TLV types. The chassis ID, port
+// ID, and time to live TLVs are mandatory, and must appear in that order at
+// the beginning of every LLDP Frame.
+const (
+	LLDPTLVEnd                  = 0
+	LLDPTLVChassisID            = 1
+	LLDPTLVPortID               = 2
+	LLDPTLVTTL                  = 3
+	LLDPTLVPortDescription      = 4
+	LLDPTLVSystemName           = 5
+	LLDPTLVSystemDescription    = 6
+	LLDPTLVSystemCapabilities   = 7
+	LLDPTLVManagementAddress    = 8
+	LLDPTLVOrganizationSpecific = 127
+)
+
+const (
+	// lldpTypeMax is the maximum value of a 7-bit LLDP TLV type.
+	lldpTypeMax = 0x7f
+
+	// lldpLengthMax is the maximum length of a 9-bit LLDP TLV value.
+	lldpLengthMax = 0x1ff
+)
+
+var (
+	// ErrInvalidLLDP is returned when an LLDP Frame or LLDPTLV is invalid.
+	ErrInvalidLLDP = errors.New("invalid LLDP frame")
+)
+
+// An LLDPTLV is a single type-length-value element carried in the payload of
+// an IEEE 802.1AB Link Layer Discovery Protocol Frame.
+type LLDPTLV struct {
+	// Type specifies the 7-bit type of the TLV, such as LLDPTLVChassisID.
+	Type uint8
+
+	// Value specifies the value of the TLV, which may be at most 511 bytes
+	// in length. The length of the TLV is computed from Value.
+	Value []byte
+}
+
+// NewLLDPFrame creates an IEEE 802.1AB Link Layer Discovery Protocol Frame
+// from the hardware address src, whose payload contains tlvs followed by an
+// end of LLDPDU TLV. The Frame is addressed to the nearest bridge multicast
+// address.
+//
+// The first three elements of tlvs must be the mandatory chassis ID, port ID,
+// and time to live TLVs, in that order. tlvs must not contain an end of
+// LLDPDU TLV. If tlvs is invalid, or a TLV's type or value is too large,
+// ErrInvalidLLDP is returned.
+func NewLLDPFrame(src net.HardwareAddr, tlvs []LLDPTLV) (*Frame, error) {
+	if err := validateLLDPTLVs(tlvs); err != nil {
+		return nil, err
+	}
+
+	// Each TLV has a 2 byte header, followed by a 2 byte end of LLDPDU TLV.
+	n := 2
+	for _, t := range tlvs {
+		if t.Type == LLDPTLVEnd || t.Type > lldpTypeMax {
+			return nil, fmt.Errorf("%w: TLV type %d not permitted", ErrInvalidLLDP, t.Type)
+		}
+		if len(t.Value) > lldpLengthMax {
+			return nil, fmt.Errorf("%w: TLV type %d value too long: %d bytes",
+				ErrInvalidLLDP, t.Type, len(t.Value))
+		}
+
+		n += 2 + len(t.Value)
+	}
+
+	p := make([]byte, n)
+	i := 0
+	for _, t := range tlvs {
+		binary.BigEndian.PutUint16(p[i:i+2], uint16(t.Type)<<9|uint16(len(t.Value)))
+		copy(p[i+2:], t.Value)
+		i += 2 + len(t.Value)
+	}
+
+	// The final 2 bytes are left zero for the end of LLDPDU TLV.
+
+	return &Frame{
+		Destination: cloneBytes(MulticastLLDP),
+		Source:      src,
+		EtherType:   EtherTypeLLDP,
+		Payload:     p,
+	}, nil
+}
+
+// ParseLLDP returns the TLVs contained in the payload of an IEEE 802.1AB
+// Link Layer Discovery Protocol Frame. The end of LLDPDU TLV, and any padding
+// which follows it, are not returned. The Value of each TLV aliases the
+// Frame's payload.
+//
+// If f does not have EtherType EtherTypeLLDP, its payload is truncated, or
+// the mandatory chassis ID, port ID, and time to live TLVs are not present,
+// ErrInvalidLLDP is returned.
+func ParseLLDP(f *Frame) ([]LLDPTLV, error) {
+	if f.EtherType != EtherTypeLLDP {
+		return nil, fmt.Errorf("%w: EtherType %v", ErrInvalidLLDP, f.EtherType)
+	}
+
+	var tlvs []LLDPTLV
+	b := f.Payload
+	for {
+		if len(b) < 2 {
+			return nil, fmt.Errorf("%w: truncated TLV header", ErrInvalidLLDP)
+		}
+
+		h := binary.BigEndian.Uint16(b[0:2])
+		t, n := uint8(h>>9), int(h&lldpLengthMax)
+		if t == LLDPTLVEnd {
+			break
+		}
+
+		if len(b[2:]) < n {
+			return nil, fmt.Errorf("%w: truncated TLV type %d", ErrInvalidLLDP, t)
+		}
+
+		tlvs = append(tlvs, LLDPTLV{
+			Type:  t,
+			Value: b[2 : 2+n],
+		})
+		b = b[2+n:]
+	}
+
+	if err := validateLLDPTLVs(tlvs); err != nil {
+		return nil, err
+	}
+
+	return tlvs, nil
+}
+
+// validateLLDPTLVs verifies that the mandatory chassis ID, port ID, and time
+// to live TLVs appear in order at the beginning of tlvs.
+func validateLLDPTLVs(tlvs []LLDPTLV) error {
+	mandatory := []uint8{LLDPTLVChassisID, LLDPTLVPortID, LLDPTLVTTL}
+	for i, t := range mandatory {
+		if i >= len(tlvs) || tlvs[i].Type != t {
+			return fmt.Errorf("%w: missing mandatory TLV type %d", ErrInvalidLLDP, t)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,239 @@
+package ethernet
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestNewLLDPFrame(t *testing.T) {
+	src := net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66}
+
+	mandatory := []LLDPTLV{
+		{Type: LLDPTLVChassisID, Value: []byte{0x04, 0x00, 0x16, 0x3e, 0x44, 0x55, 0x66}},
+		{Type: LLDPTLVPortID, Value: []byte{0x05, 'e', 't', 'h', '0'}},
+		{Type: LLDPTLVTTL, Value: []byte{0x00, 0x78}},
+	}
+
+	var tests = []struct {
+		desc string
+		tlvs []LLDPTLV
+		p    []byte
+		err  error
+	}{
+		{
+			desc: "no TLVs",
+			err:  ErrInvalidLLDP,
+		},
+		{
+			desc: "mandatory TLVs out of order",
+			tlvs: []LLDPTLV{mandatory[1], mandatory[0], mandatory[2]},
+			err:  ErrInvalidLLDP,
+		},
+		{
+			desc: "missing TTL",
+			tlvs: mandatory[:2],
+			err:  ErrInvalidLLDP,
+		},
+		{
+			desc: "end TLV",
+			tlvs: append(append([]LLDPTLV(nil), mandatory...), LLDPTLV{Type: LLDPTLVEnd}),
+			err:  ErrInvalidLLDP,
+		},
+		{
+			desc: "type too large",
+			tlvs: append(append([]LLDPTLV(nil), mandatory...), LLDPTLV{Type: 128}),
+			err:  ErrInvalidLLDP,
+		},
+		{
+			desc: "value too long",
+			tlvs: append(append([]LLDPTLV(nil), mandatory...), LLDPTLV{
+				Type:  LLDPTLVSystemDescription,
+				Value: make([]byte, 512),
+			}),
+			err: ErrInvalidLLDP,
+		},
+		{
+			desc: "OK",
+			tlvs: append(append([]LLDPTLV(nil), mandatory...), LLDPTLV{
+				Type:  LLDPTLVSystemName,
+				Value: []byte("sw1"),
+			}),
+			p: []byte{
+				0x02, 0x07, 0x04, 0x00, 0x16, 0x3e, 0x44, 0x55, 0x66,
+				0x04, 0x05, 0x05, 'e', 't', 'h', '0',
+				0x06, 0x02, 0x00, 0x78,
+				0x0a, 0x03, 's', 'w', '1',
+				0x00, 0x00,
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f, err := NewLLDPFrame(src, tt.tlvs)
+			if want, got := tt.err, err; !errors.Is(got, want) {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+			if err != nil {
+				return
+			}
+
+			want := &Frame{
+				Destination: net.HardwareAddr{0x01, 0x80, 0xc2, 0x00, 0x00, 0x0e},
+				Source:      src,
+				EtherType:   EtherTypeLLDP,
+				Payload:     tt.p,
+			}
+
+			if !reflect.DeepEqual(want, f) {
+				t.Fatalf("[%02d] test %q, unexpected Frame:\n- want: %v\n- got: %v",
+					i, tt.desc, want, f)
+			}
+		})
+	}
+}
+
+func TestParseLLDP(t *testing.T) {
+	var tests = []struct {
+		desc string
+		f    *Frame
+		tlvs []LLDPTLV
+		err  error
+	}{
+		{
+			desc: "not LLDP",
+			f: &Frame{
+				EtherType: EtherTypeIPv4,
+			},
+			err: ErrInvalidLLDP,
+		},
+		{
+			desc: "empty payload",
+			f: &Frame{
+				EtherType: EtherTypeLLDP,
+			},
+			err: ErrInvalidLLDP,
+		},
+		{
+			desc: "truncated TLV",
+			f: &Frame{
+				EtherType: EtherTypeLLDP,
+				Payload:   []byte{0x02, 0x07, 0x04},
+			},
+			err: ErrInvalidLLDP,
+		},
+		{
+			desc: "missing end TLV",
+			f: &Frame{
+				EtherType: EtherTypeLLDP,
+				Payload: []byte{
+					0x02, 0x01, 0xaa,
+					0x04, 0x01, 0xbb,
+					0x06, 0x02, 0x00, 0x78,
+				},
+			},
+			err: ErrInvalidLLDP,
+		},
+		{
+			desc: "missing mandatory TLV",
+			f: &Frame{
+				EtherType: EtherTypeLLDP,
+				Payload: []byte{
+					0x02, 0x01, 0xaa,
+					0x06, 0x02, 0x00, 0x78,
+					0x00, 0x00,
+				},
+			},
+			err: ErrInvalidLLDP,
+		},
+		{
+			desc: "OK, padded",
+			f: &Frame{
+				EtherType: EtherTypeLLDP,
+				Payload: append([]byte{
+					0x02, 0x01, 0xaa,
+					0x04, 0x01, 0xbb,
+					0x06, 0x02, 0x00, 0x78,
+					0x0a, 0x03, 's', 'w', '1',
+					0x00, 0x00,
+				}, make([]byte, 29)...),
+			},
+			tlvs: []LLDPTLV{
+				{Type: LLDPTLVChassisID, Value: []byte{0xaa}},
+				{Type: LLDPTLVPortID, Value: []byte{0xbb}},
+				{Type: LLDPTLVTTL, Value: []byte{0x00, 0x78}},
+				{Type: LLDPTLVSystemName, Value: []byte("sw1")},
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			tlvs, err := ParseLLDP(tt.f)
+			if want, got := tt.err, err; !errors.Is(got, want) {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.tlvs, tlvs; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected TLVs:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestLLDPRoundTrip(t *testing.T) {
+	tlvs := []LLDPTLV{
+		{Type: LLDPTLVChassisID, Value: []byte{0x07, 's', 'w', '1'}},
+		{Type: LLDPTLVPortID, Value: []byte{0x07, '1', '/', '1'}},
+		{Type: LLDPTLVTTL, Value: []byte{0x00, 0x78}},
+		{Type: LLDPTLVOrganizationSpecific, Value: []byte{0x00, 0x80, 0xc2, 0x01, 0x00, 0x0a}},
+	}
+
+	f, err := NewLLDPFrame(net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66}, tlvs)
+	if err != nil {
+		t.Fatalf("failed to create LLDP Frame: %v", err)
+	}
+
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+
+	f2 := new(Frame)
+	if err := f2.UnmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal Frame: %v", err)
+	}
+
+	got, err := ParseLLDP(f2)
+	if err != nil {
+		t.Fatalf("failed to parse LLDP Frame: %v", err)
+	}
+
+	if !reflect.DeepEqual(tlvs, got) {
+		t.Fatalf("unexpected TLVs:\n- want: %v\n- got: %v", tlvs, got)
+	}
+}
+
+func TestNewLLDPFrameDoesNotAliasAddress(t *testing.T) {
+	want := net.HardwareAddr{0x01, 0x80, 0xc2, 0x00, 0x00, 0x0e}
+
+	f, err := NewLLDPFrame(net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66}, []LLDPTLV{
+		{Type: LLDPTLVChassisID, Value: []byte{0x07, 's', 'w', '1'}},
+		{Type: LLDPTLVPortID, Value: []byte{0x07, '1', '/', '1'}},
+		{Type: LLDPTLVTTL, Value: []byte{0x00, 0x78}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create LLDP Frame: %v", err)
+	}
+
+	// Modifying a returned Frame must not modify the package variable.
+	f.Destination[5] = 0xff
+	if got := MulticastLLDP; !reflect.DeepEqual(want, got) {
+		t.Fatalf("MulticastLLDP modified: %v != %v", want, got)
+	}
+}
@@ -0,0 +1,173 @@
+package ethernet
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net"
+)
+
+var (
+	// ErrInvalidMAC is returned when a hardware address is not a valid
+	// 48-bit MAC address.
+	ErrInvalidMAC = errors.New("invalid MAC address")
+)
+
+// Compile-time assertions that MAC implements the text encoding interfaces.
+var (
+	_ encoding.TextMarshaler   = MAC{}
+	_ encoding.TextUnmarshaler = (*MAC)(nil)
+)
+
+// A MAC is a 48-bit IEEE MAC-48 hardware address. Unlike net.HardwareAddr,
+// a MAC is a value type: it is comparable, and may be used as a map key.
+//
+// A MAC implements encoding.TextMarshaler and encoding.TextUnmarshaler, so
+// it is encoded as a string such as "00:16:3e:11:22:33" by packages such
+// as encoding/json.
+type MAC [6]byte
+
+// ParseMAC parses s as a 48-bit MAC address, using any of the formats
+// accepted by net.ParseMAC. If s is not a valid 48-bit MAC address, an error
+// wrapping ErrInvalidMAC is returned.
+func ParseMAC(s string) (MAC, error) {
+	hw, err := net.ParseMAC(s)
+	if err != nil || len(hw) != len(MAC{}) {
+		return MAC{}, fmt.Errorf("%w: %q", ErrInvalidMAC, s)
+	}
+
+	return MACFromHardwareAddr(hw)
+}
+
+// MACFromHardwareAddr converts a net.HardwareAddr to a MAC. If hw is not
+// exactly 6 bytes in length, an error wrapping ErrInvalidMAC is returned.
+func MACFromHardwareAddr(hw net.HardwareAddr) (MAC, error) {
+	var m MAC
+	if len(hw) != len(m) {
+		return MAC{}, fmt.Errorf("%w: length %d", ErrInvalidMAC, len(hw))
+	}
+
+	copy(m[:], hw)
+	return m, nil
+}
+
+// HardwareAddr returns a newly allocated net.HardwareAddr containing m.
+func (m MAC) HardwareAddr() net.HardwareAddr {
+	return net.HardwareAddr{m[0], m[1], m[2], m[3], m[4], m[5]}
+}
+
+// String returns m in the form "00:16:3e:11:22:33".
+func (m MAC) String() string {
+	return net.HardwareAddr(m[:]).String()
+}
+
+// OUI returns the organizationally unique identifier of m: its first 3
+// bytes, which identify the vendor of a universally administered address.
+func (m MAC) OUI() [3]byte {
+	return [3]byte{m[0], m[1], m[2]}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (m MAC) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, using the formats
+// accepted by ParseMAC.
+func (m *MAC) UnmarshalText(b []byte) error {
+	mac, err := ParseMAC(string(b))
+	if err != nil {
+		return err
+	}
+
+	*m = mac
+	return nil
+}
+
+// DestinationMAC returns the Frame's destination hardware address as a MAC,
+// and true. If the destination is not exactly 6 bytes in length, it returns
+// false.
+func (f *Frame) DestinationMAC() (MAC, bool) {
+	m, err := MACFromHardwareAddr(f.Destination)
+	return m, err == nil
+}
+
+// SourceMAC returns the Frame's source hardware address as a MAC, and true.
+// If the source is not exactly 6 bytes in length, it returns false.
+func (f *Frame) SourceMAC() (MAC, bool) {
+	m, err := MACFromHardwareAddr(f.Source)
+	return m, err == nil
+}
+
+// SetDestinationArray sets the Frame's destination hardware address to a,
+// without allocating: the Destination field references storage owned by the
+// Frame, rather than a newly allocated net.HardwareAddr, which is useful for
+// reducing allocations when generating many Frames.
+//
+// Because the storage is owned by the Frame, Destination is overwritten by
+// the next call to SetDestinationArray, and a shallow copy of the Frame
+// shares it with the original; use Clone to copy a Frame.
+func (f *Frame) SetDestinationArray(a [6]byte) {
+	copy(f.addrs[0:6], a[:])
+	f.Destination = f.addrs[0:6:6]
+}
+
+// SetSourceArray is like SetDestinationArray, but sets the Frame's source
+// hardware address.
+func (f *Frame) SetSourceArray(a [6]byte) {
+	copy(f.addrs[6:12], a[:])
+	f.Source = f.addrs[6:12:12]
+}
+
+// DestinationArray returns the Frame's destination hardware address as a
+// [6]byte, without allocating. If the destination is not exactly 6 bytes in
+// length, the zero array is returned; use DestinationMAC to detect this case.
+func (f *Frame) DestinationArray() [6]byte {
+	m, _ := f.DestinationMAC()
+	return m
+}
+
+// SourceArray returns the Frame's source hardware address as a [6]byte,
+// without allocating. If the source is not exactly 6 bytes in length, the
+// zero array is returned; use SourceMAC to detect this case.
+func (f *Frame) SourceArray() [6]byte {
+	m, _ := f.SourceMAC()
+	return m
+}
+
+// Direction heuristically labels the direction of a Frame relative to a
+// local network whose devices use the organizationally unique identifiers in
+// localOUIs, which is useful for labeling traffic in monitoring tools without
+// deeper inspection.
+//
+// Direction returns "outbound" if only the Frame's source has a local OUI,
+// "inbound" if only its destination has a local OUI, and "unknown" if both
+// or neither do, or if either hardware address is not exactly 6 bytes in
+// length.
+func (f *Frame) Direction(localOUIs [][3]byte) string {
+	src, sok := f.SourceMAC()
+	dst, dok := f.DestinationMAC()
+	if !sok || !dok {
+		return "unknown"
+	}
+
+	local := func(m MAC) bool {
+		oui := m.OUI()
+		for _, l := range localOUIs {
+			if oui == l {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	switch srcLocal, dstLocal := local(src), local(dst); {
+	case srcLocal && !dstLocal:
+		return "outbound"
+	case dstLocal && !srcLocal:
+		return "inbound"
+	default:
+		return "unknown"
+	}
+}
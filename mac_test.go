@@ -0,0 +1,302 @@
+package ethernet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestParseMAC(t *testing.T) {
+	var tests = []struct {
+		desc string
+		s    string
+		m    MAC
+		err  error
+	}{
+		{
+			desc: "empty",
+			err:  ErrInvalidMAC,
+		},
+		{
+			desc: "malformed",
+			s:    "00:16:3e:11:22",
+			err:  ErrInvalidMAC,
+		},
+		{
+			desc: "EUI-64",
+			s:    "00:16:3e:11:22:33:44:55",
+			err:  ErrInvalidMAC,
+		},
+		{
+			desc: "colons",
+			s:    "00:16:3e:11:22:33",
+			m:    MAC{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33},
+		},
+		{
+			desc: "hyphens",
+			s:    "00-16-3E-11-22-33",
+			m:    MAC{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33},
+		},
+		{
+			desc: "dots",
+			s:    "0016.3e11.2233",
+			m:    MAC{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			m, err := ParseMAC(tt.s)
+			if want, got := tt.err, err; !errors.Is(got, want) {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.m, m; want != got {
+				t.Fatalf("[%02d] test %q, unexpected MAC: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestMACHardwareAddr(t *testing.T) {
+	hw := net.HardwareAddr{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33}
+
+	m, err := MACFromHardwareAddr(hw)
+	if err != nil {
+		t.Fatalf("failed to convert hardware address: %v", err)
+	}
+
+	if want, got := hw, m.HardwareAddr(); !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected hardware address: %v != %v", want, got)
+	}
+
+	if want, got := "00:16:3e:11:22:33", m.String(); want != got {
+		t.Fatalf("unexpected string: %q != %q", want, got)
+	}
+
+	if _, err := MACFromHardwareAddr(hw[:5]); !errors.Is(err, ErrInvalidMAC) {
+		t.Fatalf("unexpected error for short hardware address: %v", err)
+	}
+}
+
+func TestMACJSON(t *testing.T) {
+	in := map[string]MAC{
+		"gateway": {0x00, 0x16, 0x3e, 0x11, 0x22, 0x33},
+	}
+
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("failed to marshal JSON: %v", err)
+	}
+
+	if want, got := `{"gateway":"00:16:3e:11:22:33"}`, string(b); want != got {
+		t.Fatalf("unexpected JSON: %s != %s", want, got)
+	}
+
+	var out map[string]MAC
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("failed to unmarshal JSON: %v", err)
+	}
+
+	if !reflect.DeepEqual(in, out) {
+		t.Fatalf("unexpected MACs:\n- want: %v\n- got: %v", in, out)
+	}
+
+	var m MAC
+	if err := json.Unmarshal([]byte(`"foo"`), &m); !errors.Is(err, ErrInvalidMAC) {
+		t.Fatalf("unexpected error for invalid JSON MAC: %v", err)
+	}
+}
+
+func TestFrameMACs(t *testing.T) {
+	f := &Frame{
+		Destination: Broadcast,
+		Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55},
+	}
+
+	dst, ok := f.DestinationMAC()
+	if !ok {
+		t.Fatal("expected valid destination MAC")
+	}
+	if want, got := (MAC{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}), dst; want != got {
+		t.Fatalf("unexpected destination MAC: %v != %v", want, got)
+	}
+
+	if _, ok := f.SourceMAC(); ok {
+		t.Fatal("expected invalid source MAC")
+	}
+}
+
+func TestFrameSetAddressArrays(t *testing.T) {
+	var (
+		dst = [6]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+		src = [6]byte{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33}
+	)
+
+	f := &Frame{
+		EtherType: EtherTypeIPv4,
+		Payload:   bytes.Repeat([]byte{0}, 50),
+	}
+	f.SetDestinationArray(dst)
+	f.SetSourceArray(src)
+
+	if want, got := dst, f.DestinationArray(); want != got {
+		t.Fatalf("unexpected destination: %v != %v", want, got)
+	}
+	if want, got := src, f.SourceArray(); want != got {
+		t.Fatalf("unexpected source: %v != %v", want, got)
+	}
+
+	want := &Frame{
+		Destination: Broadcast,
+		Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33},
+		EtherType:   EtherTypeIPv4,
+		Payload:     bytes.Repeat([]byte{0}, 50),
+	}
+	if !want.Equal(f) {
+		t.Fatalf("unexpected Frame:\n- want: %v\n- got: %v", want, f)
+	}
+
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+	wb, err := want.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+	if !bytes.Equal(wb, b) {
+		t.Fatalf("unexpected Frame bytes:\n- want: %v\n- got: %v", wb, b)
+	}
+
+	// The addresses must not be able to overwrite each other.
+	f.Destination = append(f.Destination, 0x00)
+	if want, got := src, f.SourceArray(); want != got {
+		t.Fatalf("unexpected source after append: %v != %v", want, got)
+	}
+
+	// A clone must not share the Frame's address storage.
+	f.SetDestinationArray(dst)
+	c := f.Clone()
+	f.SetDestinationArray(src)
+	if want, got := dst, c.DestinationArray(); want != got {
+		t.Fatalf("unexpected cloned destination: %v != %v", want, got)
+	}
+
+	allocs := testing.AllocsPerRun(10, func() {
+		f.SetDestinationArray(dst)
+		f.SetSourceArray(src)
+		_ = f.DestinationArray()
+		_ = f.SourceArray()
+	})
+	if allocs != 0 {
+		t.Fatalf("unexpected allocations: %v", allocs)
+	}
+
+	// An invalid address produces the zero array.
+	f.Source = net.HardwareAddr{0x00, 0x16}
+	if want, got := ([6]byte{}), f.SourceArray(); want != got {
+		t.Fatalf("unexpected invalid source: %v != %v", want, got)
+	}
+}
+
+func TestMACOUI(t *testing.T) {
+	m := MAC{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33}
+	if want, got := [3]byte{0x00, 0x16, 0x3e}, m.OUI(); want != got {
+		t.Fatalf("unexpected OUI: %v != %v", want, got)
+	}
+}
+
+func TestFrameDirection(t *testing.T) {
+	var (
+		local  = net.HardwareAddr{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33}
+		local2 = net.HardwareAddr{0x52, 0x54, 0x00, 0x11, 0x22, 0x33}
+		remote = net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad}
+
+		ouis = [][3]byte{
+			{0x00, 0x16, 0x3e},
+			{0x52, 0x54, 0x00},
+		}
+	)
+
+	var tests = []struct {
+		desc string
+		src  net.HardwareAddr
+		dst  net.HardwareAddr
+		ouis [][3]byte
+		dir  string
+	}{
+		{
+			desc: "empty",
+			ouis: ouis,
+			dir:  "unknown",
+		},
+		{
+			desc: "short source",
+			src:  local[:5],
+			dst:  remote,
+			ouis: ouis,
+			dir:  "unknown",
+		},
+		{
+			desc: "no local OUIs",
+			src:  local,
+			dst:  remote,
+			dir:  "unknown",
+		},
+		{
+			desc: "outbound",
+			src:  local,
+			dst:  remote,
+			ouis: ouis,
+			dir:  "outbound",
+		},
+		{
+			desc: "outbound, broadcast",
+			src:  local2,
+			dst:  Broadcast,
+			ouis: ouis,
+			dir:  "outbound",
+		},
+		{
+			desc: "inbound",
+			src:  remote,
+			dst:  local2,
+			ouis: ouis,
+			dir:  "inbound",
+		},
+		{
+			desc: "both local",
+			src:  local,
+			dst:  local2,
+			ouis: ouis,
+			dir:  "unknown",
+		},
+		{
+			desc: "both remote",
+			src:  remote,
+			dst:  Broadcast,
+			ouis: ouis,
+			dir:  "unknown",
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f := &Frame{
+				Destination: tt.dst,
+				Source:      tt.src,
+			}
+
+			if want, got := tt.dir, f.Direction(tt.ouis); want != got {
+				t.Fatalf("[%02d] test %q, unexpected direction: %q != %q",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package ethernet
+
+import (
+	"time"
+)
+
+// Default limits used by a Reassembler when its fields are zero.
+const (
+	defaultReassemblyTimeout = 30 * time.Second
+	defaultMaxPending        = 64
+	defaultMaxFragments      = 64
+)
+
+// A Fragment is a fragment of a message carried by a Frame, as identified by
+// a FragmentFunc.
+type Fragment struct {
+	// ID identifies the message to which the Fragment belongs. IDs need only
+	// be unique for each source hardware address.
+	ID uint64
+
+	// Index is the position of the Fragment within its message, starting
+	// at 0.
+	Index int
+
+	// Last reports whether the Fragment is the final Fragment of its
+	// message.
+	Last bool
+
+	// Data is the portion of the message carried by the Fragment.
+	Data []byte
+}
+
+// A FragmentFunc extracts a Fragment from a Frame carrying a custom protocol,
+// typically by parsing a header in its payload. If the Frame does not carry
+// a Fragment, a FragmentFunc returns false.
+type FragmentFunc func(f *Frame) (Fragment, bool)
+
+// A Reassembler reassembles messages which are fragmented across multiple
+// Frames by a custom layer 2 protocol. Fragments are identified by a
+// FragmentFunc, and are keyed by the source hardware address of each Frame
+// and the ID of each Fragment, so fragments may arrive in any order, and the
+// fragments of different messages may be interleaved.
+//
+// To bound the memory used by incomplete messages, a Reassembler discards:
+//   - incomplete messages whose first fragment arrived more than Timeout
+//     before the most recent Frame
+//   - the oldest incomplete message, when a fragment for a new message
+//     arrives and MaxPending incomplete messages are already buffered
+//   - fragments with an Index of MaxFragments or more
+//
+// Times are taken from each Frame's Timestamp, so that captures may be
+// reassembled as they were received, or from the current time if a Frame's
+// Timestamp is zero.
+//
+// A Reassembler is not safe for concurrent use by multiple goroutines.
+type Reassembler struct {
+	// Timeout specifies how long an incomplete message is buffered, measured
+	// from the arrival of its first fragment. If zero, 30 seconds is used.
+	Timeout time.Duration
+
+	// MaxPending specifies the maximum number of incomplete messages which
+	// are buffered. If zero, 64 is used.
+	MaxPending int
+
+	// MaxFragments specifies the maximum number of fragments in a message.
+	// If zero, 64 is used.
+	MaxFragments int
+
+	fn      FragmentFunc
+	pending map[reassemblyKey]*partialMessage
+}
+
+// A reassemblyKey identifies a message being reassembled.
+type reassemblyKey struct {
+	src MAC
+	id  uint64
+}
+
+// A partialMessage is an incomplete message being reassembled.
+type partialMessage struct {
+	start     time.Time
+	fragments map[int][]byte
+	last      int
+}
+
+// NewReassembler creates a Reassembler which uses fn to extract Fragments
+// from Frames.
+func NewReassembler(fn FragmentFunc) *Reassembler {
+	return &Reassembler{
+		fn:      fn,
+		pending: make(map[reassemblyKey]*partialMessage),
+	}
+}
+
+// Add adds a Frame to the Reassembler. If the Frame carries the final
+// missing fragment of a message, Add returns the complete message, formed by
+// concatenating the data of its fragments in order of their indices, and
+// true. Otherwise, Add returns false.
+//
+// The data of each fragment is copied, so the Frame may be reused once Add
+// returns. Frames which do not carry a fragment, which have a source hardware
+// address that is not 6 bytes in length, or which carry a duplicate fragment
+// or a fragment following the final fragment of its message are ignored.
+func (r *Reassembler) Add(f *Frame) (complete []byte, done bool) {
+	now := f.Timestamp
+	if now.IsZero() {
+		now = time.Now()
+	}
+	r.expire(now)
+
+	frag, ok := r.fn(f)
+	if !ok || frag.Index < 0 || frag.Index >= r.maxFragments() {
+		return nil, false
+	}
+
+	src, ok := f.SourceMAC()
+	if !ok {
+		return nil, false
+	}
+
+	k := reassemblyKey{src: src, id: frag.ID}
+	m, ok := r.pending[k]
+	if !ok {
+		if len(r.pending) >= r.maxPending() {
+			r.evictOldest()
+		}
+
+		m = &partialMessage{
+			start:     now,
+			fragments: make(map[int][]byte),
+			last:      -1,
+		}
+		r.pending[k] = m
+	}
+
+	if _, ok := m.fragments[frag.Index]; ok {
+		return nil, false
+	}
+
+	// Once the final fragment is known, discard any fragments which claim
+	// to follow it.
+	if m.last >= 0 && (frag.Last || frag.Index > m.last) {
+		return nil, false
+	}
+	if frag.Last {
+		m.last = frag.Index
+		for i := range m.fragments {
+			if i > m.last {
+				delete(m.fragments, i)
+			}
+		}
+	}
+
+	m.fragments[frag.Index] = append([]byte(nil), frag.Data...)
+
+	// The message is complete once the final fragment and every fragment
+	// which precedes it have arrived.
+	if m.last < 0 || len(m.fragments) != m.last+1 {
+		return nil, false
+	}
+
+	delete(r.pending, k)
+
+	var n int
+	for _, b := range m.fragments {
+		n += len(b)
+	}
+
+	complete = make([]byte, 0, n)
+	for i := 0; i <= m.last; i++ {
+		complete = append(complete, m.fragments[i]...)
+	}
+
+	return complete, true
+}
+
+// Pending returns the number of incomplete messages which are buffered.
+func (r *Reassembler) Pending() int {
+	return len(r.pending)
+}
+
+// expire discards incomplete messages which have timed out at time now.
+func (r *Reassembler) expire(now time.Time) {
+	timeout := r.Timeout
+	if timeout == 0 {
+		timeout = defaultReassemblyTimeout
+	}
+
+	for k, m := range r.pending {
+		if now.Sub(m.start) > timeout {
+			delete(r.pending, k)
+		}
+	}
+}
+
+// evictOldest discards the incomplete message which started first.
+func (r *Reassembler) evictOldest() {
+	var (
+		oldest reassemblyKey
+		start  time.Time
+		found  bool
+	)
+	for k, m := range r.pending {
+		if !found || m.start.Before(start) {
+			oldest, start, found = k, m.start, true
+		}
+	}
+
+	delete(r.pending, oldest)
+}
+
+// maxPending returns the maximum number of incomplete messages.
+func (r *Reassembler) maxPending() int {
+	if r.MaxPending == 0 {
+		return defaultMaxPending
+	}
+
+	return r.MaxPending
+}
+
+// maxFragments returns the maximum number of fragments in a message.
+func (r *Reassembler) maxFragments() int {
+	if r.MaxFragments == 0 {
+		return defaultMaxFragments
+	}
+
+	return r.MaxFragments
+}
@@ -0,0 +1,207 @@
+package ethernet
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+)
+
+// testFragment extracts a Fragment from a Frame with EtherType
+// EtherTypeLocalExperimental1, whose payload begins with a message ID,
+// fragment index, and final fragment flag, each 1 byte in length.
+func testFragment(f *Frame) (Fragment, bool) {
+	if f.EtherType != EtherTypeLocalExperimental1 || len(f.Payload) < 3 {
+		return Fragment{}, false
+	}
+
+	return Fragment{
+		ID:    uint64(f.Payload[0]),
+		Index: int(f.Payload[1]),
+		Last:  f.Payload[2] != 0,
+		Data:  f.Payload[3:],
+	}, true
+}
+
+// fragmentFrame creates a Frame carrying a fragment for testFragment.
+func fragmentFrame(src byte, id, index byte, last bool, data string, ts time.Time) *Frame {
+	var l byte
+	if last {
+		l = 1
+	}
+
+	return &Frame{
+		Destination: Broadcast,
+		Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, src},
+		EtherType:   EtherTypeLocalExperimental1,
+		Payload:     append([]byte{id, index, l}, data...),
+		Timestamp:   ts,
+	}
+}
+
+func TestReassembler(t *testing.T) {
+	t0 := time.Unix(1, 0)
+
+	var tests = []struct {
+		desc    string
+		r       *Reassembler
+		frames  []*Frame
+		msgs    []string
+		pending int
+	}{
+		{
+			desc: "not a fragment",
+			frames: []*Frame{
+				{EtherType: EtherTypeIPv4, Payload: []byte{0, 0, 1}},
+			},
+		},
+		{
+			desc: "short source",
+			frames: []*Frame{
+				{EtherType: EtherTypeLocalExperimental1, Payload: []byte{0, 0, 1}},
+			},
+		},
+		{
+			desc: "single fragment",
+			frames: []*Frame{
+				fragmentFrame(1, 1, 0, true, "hello", t0),
+			},
+			msgs: []string{"hello"},
+		},
+		{
+			desc: "in order",
+			frames: []*Frame{
+				fragmentFrame(1, 1, 0, false, "foo", t0),
+				fragmentFrame(1, 1, 1, false, "bar", t0),
+				fragmentFrame(1, 1, 2, true, "baz", t0),
+			},
+			msgs: []string{"foobarbaz"},
+		},
+		{
+			desc: "out of order, duplicate",
+			frames: []*Frame{
+				fragmentFrame(1, 1, 2, true, "baz", t0),
+				fragmentFrame(1, 1, 0, false, "foo", t0),
+				fragmentFrame(1, 1, 0, false, "xxx", t0),
+				fragmentFrame(1, 1, 1, false, "bar", t0),
+			},
+			msgs: []string{"foobarbaz"},
+		},
+		{
+			desc: "interleaved sources and IDs",
+			frames: []*Frame{
+				fragmentFrame(1, 1, 0, false, "a", t0),
+				fragmentFrame(2, 1, 0, false, "b", t0),
+				fragmentFrame(1, 2, 0, false, "c", t0),
+				fragmentFrame(2, 1, 1, true, "B", t0),
+				fragmentFrame(1, 1, 1, true, "A", t0),
+			},
+			msgs:    []string{"bB", "aA"},
+			pending: 1,
+		},
+		{
+			desc: "fragment after final fragment",
+			frames: []*Frame{
+				fragmentFrame(1, 1, 1, true, "bar", t0),
+				fragmentFrame(1, 1, 2, false, "baz", t0),
+				fragmentFrame(1, 1, 0, false, "foo", t0),
+			},
+			msgs: []string{"foobar"},
+		},
+		{
+			desc: "timeout",
+			r:    &Reassembler{Timeout: time.Second},
+			frames: []*Frame{
+				fragmentFrame(1, 1, 0, false, "foo", t0),
+				fragmentFrame(1, 1, 1, true, "bar", t0.Add(2*time.Second)),
+			},
+			pending: 1,
+		},
+		{
+			desc: "within timeout",
+			r:    &Reassembler{Timeout: time.Second},
+			frames: []*Frame{
+				fragmentFrame(1, 1, 0, false, "foo", t0),
+				fragmentFrame(1, 1, 1, true, "bar", t0.Add(time.Second)),
+			},
+			msgs: []string{"foobar"},
+		},
+		{
+			desc: "max pending",
+			r:    &Reassembler{MaxPending: 2},
+			frames: []*Frame{
+				fragmentFrame(1, 1, 0, false, "a", t0),
+				fragmentFrame(1, 2, 0, false, "b", t0.Add(time.Millisecond)),
+				fragmentFrame(1, 3, 0, false, "c", t0.Add(2*time.Millisecond)),
+				fragmentFrame(1, 2, 1, true, "B", t0.Add(3*time.Millisecond)),
+				fragmentFrame(1, 1, 1, true, "A", t0.Add(4*time.Millisecond)),
+			},
+			msgs:    []string{"bB"},
+			pending: 2,
+		},
+		{
+			desc: "max fragments",
+			r:    &Reassembler{MaxFragments: 2},
+			frames: []*Frame{
+				fragmentFrame(1, 1, 0, false, "foo", t0),
+				fragmentFrame(1, 1, 1, false, "bar", t0),
+				fragmentFrame(1, 1, 2, true, "baz", t0),
+			},
+			pending: 1,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			r := NewReassembler(testFragment)
+			if tt.r != nil {
+				r.Timeout = tt.r.Timeout
+				r.MaxPending = tt.r.MaxPending
+				r.MaxFragments = tt.r.MaxFragments
+			}
+
+			var msgs []string
+			for _, f := range tt.frames {
+				if b, ok := r.Add(f); ok {
+					msgs = append(msgs, string(b))
+				}
+			}
+
+			if want, got := len(tt.msgs), len(msgs); want != got {
+				t.Fatalf("[%02d] test %q, unexpected number of messages: %v != %v (%q)",
+					i, tt.desc, want, got, msgs)
+			}
+			for j := range msgs {
+				if want, got := tt.msgs[j], msgs[j]; want != got {
+					t.Fatalf("[%02d] test %q, unexpected message %d: %q != %q",
+						i, tt.desc, j, want, got)
+				}
+			}
+
+			if want, got := tt.pending, r.Pending(); want != got {
+				t.Fatalf("[%02d] test %q, unexpected number of pending messages: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestReassemblerCopiesData(t *testing.T) {
+	r := NewReassembler(testFragment)
+
+	f := fragmentFrame(1, 1, 0, false, "foo", time.Unix(1, 0))
+	if _, ok := r.Add(f); ok {
+		t.Fatal("unexpected complete message")
+	}
+
+	// Reusing the Frame's payload must not affect the buffered fragment.
+	copy(f.Payload[3:], "xxx")
+
+	b, ok := r.Add(fragmentFrame(1, 1, 1, true, "bar", time.Unix(1, 0)))
+	if !ok {
+		t.Fatal("expected complete message")
+	}
+	if want, got := []byte("foobar"), b; !bytes.Equal(want, got) {
+		t.Fatalf("unexpected message: %q != %q", want, got)
+	}
+}
@@ -0,0 +1,132 @@
+package ethernet
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+)
+
+const (
+	// sllHeaderLen is the length of a Linux cooked capture (SLL) header.
+	sllHeaderLen = 16
+
+	// sllAddrLen is the maximum length of the link-layer address in an SLL
+	// header.
+	sllAddrLen = 8
+
+	// arphrdEther is the Linux ARPHRD_ETHER hardware type.
+	arphrdEther = 1
+)
+
+var (
+	// ErrInvalidSLL is returned when a Linux cooked capture (SLL) header
+	// cannot be marshaled or unmarshaled, because its link-layer address is
+	// longer than 8 bytes.
+	ErrInvalidSLL = errors.New("invalid Linux cooked capture header")
+)
+
+// An SLLPacketType is the packet type field of a Linux cooked capture (SLL)
+// header, which indicates how a Frame was received or sent by a host.
+type SLLPacketType uint16
+
+// SLLPacketType values used by Linux.
+const (
+	SLLHost      SLLPacketType = 0
+	SLLBroadcast SLLPacketType = 1
+	SLLMulticast SLLPacketType = 2
+	SLLOtherHost SLLPacketType = 3
+	SLLOutgoing  SLLPacketType = 4
+)
+
+// MarshalSLL allocates a byte slice and marshals a Frame into the Linux
+// cooked capture (SLL) format used for captures on the "any" interface,
+// with the packet type pt.
+//
+// The SLL header contains the Frame's source hardware address and an
+// Ethernet hardware type, and its protocol field is the Frame's EtherType.
+// The destination hardware address is not present in the SLL format. If
+// VLAN tags are present, the protocol field indicates a VLAN, and each tag
+// precedes the payload as in an Ethernet frame.
+//
+// If the source hardware address is longer than 8 bytes, ErrInvalidSLL is
+// returned. MarshalSLL otherwise returns the same errors as MarshalBinary.
+func (f *Frame) MarshalSLL(pt SLLPacketType) ([]byte, error) {
+	if len(f.Source) > sllAddrLen {
+		return nil, ErrInvalidSLL
+	}
+
+	// An SLL header is 2 bytes longer than the hardware addresses of an
+	// Ethernet frame, so marshal the Frame with a 2 byte offset, so that
+	// the protocol field and EtherType line up, and then overwrite the
+	// hardware addresses with the rest of the SLL header.
+	b := make([]byte, 2+f.Length())
+	if _, err := f.read(b[2:]); err != nil {
+		return nil, err
+	}
+
+	binary.BigEndian.PutUint16(b[0:2], uint16(pt))
+	binary.BigEndian.PutUint16(b[2:4], arphrdEther)
+	binary.BigEndian.PutUint16(b[4:6], uint16(len(f.Source)))
+
+	// The address field is padded with zeros.
+	addr := b[6:14]
+	for i := range addr {
+		addr[i] = 0
+	}
+	copy(addr, f.Source)
+
+	return b, nil
+}
+
+// UnmarshalSLL unmarshals a byte slice in the Linux cooked capture (SLL)
+// format into a Frame, and returns the packet type from the SLL header.
+//
+// The Frame's Source is set to the link-layer address from the SLL header,
+// and its EtherType is set from the protocol field, following any VLAN
+// tags. Because the SLL format does not contain a destination hardware
+// address, Destination is set to Broadcast for SLLBroadcast packets, and
+// is otherwise left nil.
+//
+// If the byte slice does not contain enough data to unmarshal a valid Frame,
+// io.ErrUnexpectedEOF is returned. If the link-layer address is longer than
+// 8 bytes, ErrInvalidSLL is returned. If one or more VLANs are invalid,
+// ErrInvalidVLAN is returned.
+func (f *Frame) UnmarshalSLL(b []byte) (SLLPacketType, error) {
+	if len(b) < sllHeaderLen {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	pt := SLLPacketType(binary.BigEndian.Uint16(b[0:2]))
+	alen := int(binary.BigEndian.Uint16(b[4:6]))
+	if alen > sllAddrLen {
+		return 0, ErrInvalidSLL
+	}
+
+	// The protocol field of an SLL header is 14 bytes from its beginning,
+	// so by skipping 2 bytes, VLAN tags and the EtherType can be parsed
+	// as in an Ethernet frame. The hardware addresses from parseHeader
+	// are not meaningful, and are ignored.
+	h, n, err := parseHeader(b[2:], nil, headerOptions{})
+	if err != nil {
+		return 0, err
+	}
+	n += 2
+
+	bb := make([]byte, alen+len(b[n:]))
+	copy(bb[:alen], b[6:6+alen])
+	copy(bb[alen:], b[n:])
+
+	f.resetUnmarshaled()
+	f.Destination = nil
+	if pt == SLLBroadcast {
+		f.Destination = make(net.HardwareAddr, len(Broadcast))
+		copy(f.Destination, Broadcast)
+	}
+	f.Source = net.HardwareAddr(bb[:alen])
+	f.VLAN = h.VLAN
+	f.EtherType = h.EtherType
+	f.Payload = bb[alen:]
+
+	return pt, nil
+}
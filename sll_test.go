@@ -0,0 +1,231 @@
+package ethernet
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestFrameMarshalSLL(t *testing.T) {
+	var tests = []struct {
+		desc string
+		f    *Frame
+		pt   SLLPacketType
+		b    []byte
+		err  error
+	}{
+		{
+			desc: "source address too long",
+			f: &Frame{
+				Source: make(net.HardwareAddr, 9),
+			},
+			err: ErrInvalidSLL,
+		},
+		{
+			desc: "VLAN ID too large",
+			f: &Frame{
+				VLAN: []*VLAN{{
+					ID: VLANMax,
+				}},
+			},
+			err: ErrInvalidVLAN,
+		},
+		{
+			desc: "IPv4, outgoing",
+			f: &Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				EtherType:   EtherTypeIPv4,
+				Payload:     bytes.Repeat([]byte{0xff}, 50),
+			},
+			pt: SLLOutgoing,
+			b: append([]byte{
+				0x00, 0x04,
+				0x00, 0x01,
+				0x00, 0x06,
+				1, 0, 1, 0, 1, 0, 0, 0,
+				0x08, 0x00,
+			}, bytes.Repeat([]byte{0xff}, 50)...),
+		},
+		{
+			desc: "IPv6, 1 VLAN, host",
+			f: &Frame{
+				Source: net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				VLAN: []*VLAN{{
+					Priority: 1,
+					ID:       101,
+				}},
+				EtherType: EtherTypeIPv6,
+				Payload:   bytes.Repeat([]byte{0xff}, 50),
+			},
+			pt: SLLHost,
+			b: append([]byte{
+				0x00, 0x00,
+				0x00, 0x01,
+				0x00, 0x06,
+				1, 0, 1, 0, 1, 0, 0, 0,
+				0x81, 0x00,
+				0x20, 0x65,
+				0x86, 0xdd,
+			}, bytes.Repeat([]byte{0xff}, 50)...),
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			b, err := tt.f.MarshalSLL(tt.pt)
+			if err != nil {
+				if want, got := tt.err, err; want != got {
+					t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+						i, tt.desc, want, got)
+				}
+
+				return
+			}
+
+			if want, got := tt.b, b; !bytes.Equal(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected SLL bytes:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameUnmarshalSLL(t *testing.T) {
+	var tests = []struct {
+		desc string
+		b    []byte
+		f    *Frame
+		pt   SLLPacketType
+		err  error
+	}{
+		{
+			desc: "short buffer",
+			b:    make([]byte, 15),
+			err:  io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "address too long",
+			b: []byte{
+				0x00, 0x00,
+				0x00, 0x01,
+				0x00, 0x09,
+				0, 0, 0, 0, 0, 0, 0, 0,
+				0x08, 0x00,
+			},
+			err: ErrInvalidSLL,
+		},
+		{
+			desc: "1 short VLAN",
+			b: []byte{
+				0x00, 0x00,
+				0x00, 0x01,
+				0x00, 0x06,
+				0, 0, 0, 0, 0, 0, 0, 0,
+				0x81, 0x00,
+				0x00,
+			},
+			err: io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "ARP, broadcast",
+			b: []byte{
+				0x00, 0x01,
+				0x00, 0x01,
+				0x00, 0x06,
+				1, 0, 1, 0, 1, 0, 0, 0,
+				0x08, 0x06,
+				0xde, 0xad,
+			},
+			f: &Frame{
+				Destination: Broadcast,
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				EtherType:   EtherTypeARP,
+				Payload:     []byte{0xde, 0xad},
+			},
+			pt: SLLBroadcast,
+		},
+		{
+			desc: "IPv6, 1 VLAN, other host",
+			b: []byte{
+				0x00, 0x03,
+				0x00, 0x01,
+				0x00, 0x06,
+				1, 0, 1, 0, 1, 0, 0, 0,
+				0x81, 0x00,
+				0x20, 0x65,
+				0x86, 0xdd,
+				0xde, 0xad,
+			},
+			f: &Frame{
+				Source: net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				VLAN: []*VLAN{{
+					Priority: 1,
+					ID:       101,
+				}},
+				EtherType: EtherTypeIPv6,
+				Payload:   []byte{0xde, 0xad},
+			},
+			pt: SLLOtherHost,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f := new(Frame)
+			pt, err := f.UnmarshalSLL(tt.b)
+			if err != nil {
+				if want, got := tt.err, err; want != got {
+					t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+						i, tt.desc, want, got)
+				}
+
+				return
+			}
+
+			if want, got := tt.pt, pt; want != got {
+				t.Fatalf("[%02d] test %q, unexpected packet type: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.f, f; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected Frame:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameUnmarshalSLLReuseFrame(t *testing.T) {
+	v := TestVectors[2]
+	b, err := v.Frame.MarshalSLL(SLLHost)
+	if err != nil {
+		t.Fatalf("failed to marshal SLL: %v", err)
+	}
+
+	// Fields set by a Decoder must not persist when a Frame is reused.
+	f := &Frame{
+		Trailer:       []byte{0xaa, 0xbb},
+		Raw:           []byte{0xcc, 0xdd},
+		TagsTruncated: true,
+	}
+	if _, err := f.UnmarshalSLL(b); err != nil {
+		t.Fatalf("failed to unmarshal SLL: %v", err)
+	}
+
+	if f.Trailer != nil {
+		t.Fatalf("unexpected trailer: %v", f.Trailer)
+	}
+	if f.Raw != nil {
+		t.Fatalf("unexpected raw bytes: %v", f.Raw)
+	}
+	if f.TagsTruncated {
+		t.Fatal("unexpected truncated tags")
+	}
+
+	if want, got := len(v.Bytes), f.Length(); want != got {
+		t.Fatalf("unexpected Frame length: %v != %v", want, got)
+	}
+}
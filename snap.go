@@ -0,0 +1,185 @@
+package ethernet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// snapLen is the length of an IEEE 802.2 LLC header with a SNAP extension.
+	snapLen = 8
+
+	// maxLength is the largest value of an IEEE 802.3 length field.
+	maxLength = 1500
+
+	// etherTypeAARP and etherTypeIPX are the protocols in the IEEE 802.1H
+	// selective translation table, which use bridge-tunnel encapsulation.
+	etherTypeAARP = 0x80f3
+	etherTypeIPX  = 0x8137
+)
+
+var (
+	// ErrInvalidSNAP is returned when a byte slice does not contain a valid
+	// IEEE 802.2 LLC header with a SNAP extension.
+	ErrInvalidSNAP = errors.New("invalid LLC/SNAP header")
+
+	// ErrSNAPTooLarge is returned by Frame.EncapsulateSNAP when a Frame's
+	// payload and LLC/SNAP header would exceed the maximum length of an IEEE
+	// 802.3 Frame.
+	ErrSNAPTooLarge = errors.New("LLC/SNAP payload too large")
+)
+
+// Organizationally unique identifiers used in SNAP headers to carry
+// EtherTypes. See SNAPConvention for details.
+var (
+	// OUIRFC1042 is the OUI used by RFC 1042 encapsulation.
+	OUIRFC1042 = [3]byte{0x00, 0x00, 0x00}
+
+	// OUIBridgeTunnel is the OUI used by IEEE 802.1H bridge-tunnel
+	// encapsulation.
+	OUIBridgeTunnel = [3]byte{0x00, 0x00, 0xf8}
+)
+
+// A SNAPConvention selects the OUI used when an Ethernet II Frame is
+// encapsulated in an IEEE 802.3 Frame with an LLC/SNAP header, such as when a
+// wireless bridge forwards it between IEEE 802.11 and Ethernet. There are two
+// conventions:
+//   - RFC 1042 encapsulation uses OUIRFC1042. A translating bridge converts
+//     such Frames back to Ethernet II Frames.
+//   - Bridge-tunnel encapsulation, from IEEE 802.1H, uses OUIBridgeTunnel.
+//     It marks Frames which were originally Ethernet II Frames, so that they
+//     are restored exactly, even for protocols such as AppleTalk AARP and
+//     IPX which also use LLC/SNAP natively.
+//
+// The default, SNAPSelective, uses bridge-tunnel encapsulation for the
+// protocols in the IEEE 802.1H selective translation table, AppleTalk AARP
+// (0x80f3) and IPX (0x8137), and RFC 1042 encapsulation for all others, as
+// most wireless bridges do.
+type SNAPConvention int
+
+// Possible SNAPConvention values.
+const (
+	SNAPSelective SNAPConvention = iota
+	SNAPRFC1042
+	SNAPBridgeTunnel
+)
+
+// A SNAP is an IEEE 802.2 LLC header with a SNAP extension, which carries an
+// EtherType in an IEEE 802.3 Frame.
+type SNAP struct {
+	// OUI is the organizationally unique identifier of the SNAP header,
+	// such as OUIRFC1042 or OUIBridgeTunnel.
+	OUI [3]byte
+
+	// EtherType is the protocol identifier of the SNAP header.
+	EtherType EtherType
+}
+
+// NewSNAP creates a SNAP header for EtherType et, with the OUI selected by
+// the convention c.
+func NewSNAP(et EtherType, c SNAPConvention) SNAP {
+	oui := OUIRFC1042
+	switch c {
+	case SNAPSelective:
+		if et == etherTypeAARP || et == etherTypeIPX {
+			oui = OUIBridgeTunnel
+		}
+	case SNAPBridgeTunnel:
+		oui = OUIBridgeTunnel
+	}
+
+	return SNAP{
+		OUI:       oui,
+		EtherType: et,
+	}
+}
+
+// MarshalBinary allocates a byte slice and marshals a SNAP into binary form:
+// an LLC header with DSAP and SSAP 0xaa and control field 0x03, followed by
+// the OUI and EtherType. MarshalBinary never returns an error.
+func (s SNAP) MarshalBinary() ([]byte, error) {
+	b := make([]byte, snapLen)
+	s.read(b)
+	return b, nil
+}
+
+// read reads data from a SNAP into b, which must be snapLen bytes.
+func (s SNAP) read(b []byte) {
+	b[0], b[1], b[2] = 0xaa, 0xaa, 0x03
+	copy(b[3:6], s.OUI[:])
+	binary.BigEndian.PutUint16(b[6:8], uint16(s.EtherType))
+}
+
+// UnmarshalBinary unmarshals a byte slice into a SNAP.
+//
+// If the byte slice does not contain exactly 8 bytes of data,
+// io.ErrUnexpectedEOF is returned. If the LLC header does not indicate a
+// SNAP extension, ErrInvalidSNAP is returned.
+func (s *SNAP) UnmarshalBinary(b []byte) error {
+	if len(b) != snapLen {
+		return io.ErrUnexpectedEOF
+	}
+	if b[0] != 0xaa || b[1] != 0xaa || b[2] != 0x03 {
+		return ErrInvalidSNAP
+	}
+
+	copy(s.OUI[:], b[3:6])
+	s.EtherType = EtherType(binary.BigEndian.Uint16(b[6:8]))
+	return nil
+}
+
+// EncapsulateSNAP converts an Ethernet II Frame to an IEEE 802.3 Frame with
+// an LLC/SNAP header: the Frame's EtherType is moved to a SNAP header created
+// by NewSNAP with the convention c, which is prepended to its payload, and the
+// EtherType is replaced by the length of the new payload.
+//
+// If the new payload would exceed the 1500 byte maximum length of an IEEE
+// 802.3 Frame, an error wrapping ErrSNAPTooLarge is returned, and the Frame
+// is not modified.
+func (f *Frame) EncapsulateSNAP(c SNAPConvention) error {
+	n := snapLen + len(f.Payload)
+	if n > maxLength {
+		return fmt.Errorf("%w: %d bytes exceeds maximum length %d",
+			ErrSNAPTooLarge, n, maxLength)
+	}
+
+	p := make([]byte, n)
+	NewSNAP(f.EtherType, c).read(p[:snapLen])
+	copy(p[snapLen:], f.Payload)
+
+	f.EtherType = EtherType(n)
+	f.Payload = p
+	return nil
+}
+
+// DecapsulateSNAP converts an IEEE 802.3 Frame with an LLC/SNAP header to an
+// Ethernet II Frame, reversing the operation performed by EncapsulateSNAP
+// with either convention: the Frame's EtherType is restored from the SNAP
+// header, and the payload is truncated to the length of the IEEE 802.3 Frame,
+// removing any padding. The SNAP header is returned, so that the caller may
+// inspect its OUI.
+//
+// If the Frame's EtherType is not a length, ErrNotEncapsulated is returned.
+// If its payload is shorter than the length, io.ErrUnexpectedEOF is
+// returned. If its payload does not begin with an LLC/SNAP header,
+// ErrInvalidSNAP is returned. In each case, the Frame is not modified.
+func (f *Frame) DecapsulateSNAP() (SNAP, error) {
+	n := int(f.EtherType)
+	if n > maxLength {
+		return SNAP{}, ErrNotEncapsulated
+	}
+	if n < snapLen || len(f.Payload) < n {
+		return SNAP{}, io.ErrUnexpectedEOF
+	}
+
+	var s SNAP
+	if err := s.UnmarshalBinary(f.Payload[:snapLen]); err != nil {
+		return SNAP{}, err
+	}
+
+	f.EtherType = s.EtherType
+	f.Payload = f.Payload[snapLen:n]
+	return s, nil
+}
@@ -0,0 +1,226 @@
+package ethernet
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestNewSNAP(t *testing.T) {
+	var tests = []struct {
+		desc string
+		et   EtherType
+		c    SNAPConvention
+		oui  [3]byte
+	}{
+		{
+			desc: "selective, IPv4",
+			et:   EtherTypeIPv4,
+			oui:  OUIRFC1042,
+		},
+		{
+			desc: "selective, AARP",
+			et:   0x80f3,
+			oui:  OUIBridgeTunnel,
+		},
+		{
+			desc: "selective, IPX",
+			et:   0x8137,
+			oui:  OUIBridgeTunnel,
+		},
+		{
+			desc: "RFC 1042, AARP",
+			et:   0x80f3,
+			c:    SNAPRFC1042,
+			oui:  OUIRFC1042,
+		},
+		{
+			desc: "bridge-tunnel, IPv4",
+			et:   EtherTypeIPv4,
+			c:    SNAPBridgeTunnel,
+			oui:  OUIBridgeTunnel,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s := NewSNAP(tt.et, tt.c)
+			if want, got := (SNAP{OUI: tt.oui, EtherType: tt.et}), s; want != got {
+				t.Fatalf("[%02d] test %q, unexpected SNAP: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestSNAPUnmarshalBinary(t *testing.T) {
+	var tests = []struct {
+		desc string
+		b    []byte
+		s    SNAP
+		err  error
+	}{
+		{
+			desc: "short",
+			b:    []byte{0xaa, 0xaa, 0x03, 0x00, 0x00, 0x00, 0x08},
+			err:  io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "not SNAP",
+			b:    []byte{0x42, 0x42, 0x03, 0x00, 0x00, 0x00, 0x08, 0x00},
+			err:  ErrInvalidSNAP,
+		},
+		{
+			desc: "RFC 1042, IPv4",
+			b:    []byte{0xaa, 0xaa, 0x03, 0x00, 0x00, 0x00, 0x08, 0x00},
+			s:    SNAP{OUI: OUIRFC1042, EtherType: EtherTypeIPv4},
+		},
+		{
+			desc: "bridge-tunnel, AARP",
+			b:    []byte{0xaa, 0xaa, 0x03, 0x00, 0x00, 0xf8, 0x80, 0xf3},
+			s:    SNAP{OUI: OUIBridgeTunnel, EtherType: 0x80f3},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var s SNAP
+			err := s.UnmarshalBinary(tt.b)
+			if want, got := tt.err, err; want != got {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+			if err != nil {
+				return
+			}
+
+			if want, got := tt.s, s; want != got {
+				t.Fatalf("[%02d] test %q, unexpected SNAP: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			b, err := s.MarshalBinary()
+			if err != nil {
+				t.Fatalf("[%02d] test %q, failed to marshal SNAP: %v",
+					i, tt.desc, err)
+			}
+			if want, got := tt.b, b; !bytes.Equal(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected SNAP bytes: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameEncapsulateSNAP(t *testing.T) {
+	orig := &Frame{
+		Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+		Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+		EtherType:   0x80f3,
+		Payload:     []byte{0xde, 0xad, 0xbe, 0xef},
+	}
+
+	f := orig.Clone()
+	if err := f.EncapsulateSNAP(SNAPSelective); err != nil {
+		t.Fatalf("failed to encapsulate Frame: %v", err)
+	}
+
+	if want, got := EtherType(12), f.EtherType; want != got {
+		t.Fatalf("unexpected length: %v != %v", want, got)
+	}
+	want := []byte{0xaa, 0xaa, 0x03, 0x00, 0x00, 0xf8, 0x80, 0xf3, 0xde, 0xad, 0xbe, 0xef}
+	if got := f.Payload; !bytes.Equal(want, got) {
+		t.Fatalf("unexpected payload: %v != %v", want, got)
+	}
+
+	// The encapsulated Frame must survive a round trip through its padded
+	// binary form.
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+
+	f2 := new(Frame)
+	if err := f2.UnmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal Frame: %v", err)
+	}
+
+	s, err := f2.DecapsulateSNAP()
+	if err != nil {
+		t.Fatalf("failed to decapsulate Frame: %v", err)
+	}
+	if want, got := (SNAP{OUI: OUIBridgeTunnel, EtherType: 0x80f3}), s; want != got {
+		t.Fatalf("unexpected SNAP: %v != %v", want, got)
+	}
+	if !reflect.DeepEqual(orig, f2) {
+		t.Fatalf("unexpected Frame:\n- want: %v\n- got: %v", orig, f2)
+	}
+}
+
+func TestFrameEncapsulateSNAPErrors(t *testing.T) {
+	f := &Frame{
+		EtherType: EtherTypeIPv4,
+		Payload:   make([]byte, 1493),
+	}
+	err := f.EncapsulateSNAP(SNAPSelective)
+	if !errors.Is(err, ErrSNAPTooLarge) {
+		t.Fatalf("unexpected error: %v != %v", ErrSNAPTooLarge, err)
+	}
+	if errors.Is(err, ErrFrameTooLarge) {
+		t.Fatalf("error must not match ErrFrameTooLarge: %v", err)
+	}
+	if want, got := "LLC/SNAP payload too large: 1501 bytes exceeds maximum length 1500", err.Error(); want != got {
+		t.Fatalf("unexpected error message:\n- want: %s\n- got: %s", want, got)
+	}
+	if want, got := EtherTypeIPv4, f.EtherType; want != got {
+		t.Fatalf("Frame was modified: %v != %v", want, got)
+	}
+
+	var tests = []struct {
+		desc string
+		f    *Frame
+		err  error
+	}{
+		{
+			desc: "Ethernet II",
+			f:    &Frame{EtherType: EtherTypeIPv4},
+			err:  ErrNotEncapsulated,
+		},
+		{
+			desc: "short payload",
+			f: &Frame{
+				EtherType: 12,
+				Payload:   []byte{0xaa, 0xaa, 0x03, 0x00, 0x00, 0x00, 0x08, 0x00},
+			},
+			err: io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "length too short",
+			f: &Frame{
+				EtherType: 4,
+				Payload:   []byte{0xaa, 0xaa, 0x03, 0x00, 0x00, 0x00, 0x08, 0x00},
+			},
+			err: io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "not SNAP",
+			f: &Frame{
+				EtherType: 8,
+				Payload:   []byte{0x42, 0x42, 0x03, 0x00, 0x00, 0x00, 0x08, 0x00},
+			},
+			err: ErrInvalidSNAP,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if _, err := tt.f.DecapsulateSNAP(); tt.err != err {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, tt.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package ethernet
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// ReadFrameFunc reads a single Frame from r, using lengthOf to determine
+// the total length of the Frame in bytes. ReadFrameFunc is useful for
+// protocols which encode the length of a frame within the frame itself,
+// when frames are transmitted back-to-back over a stream.
+//
+// lengthOf is first called with the Frame's header: the hardware addresses,
+// any VLAN tags, and the EtherType. If lengthOf requires more bytes to
+// determine the length of the Frame, such as a length field at the
+// beginning of the payload, it may return io.ErrShortBuffer, and it will
+// be called again with one more byte, until the buffer of r is full.
+// The header slice passed to lengthOf must not be retained or modified.
+//
+// If r contains no more data, io.EOF is returned. If r ends partway through
+// a Frame, io.ErrUnexpectedEOF is returned.
+func ReadFrameFunc(r *bufio.Reader, lengthOf func(header []byte) (int, error)) (*Frame, error) {
+	// Peek hardware addresses and EtherType, and any VLAN tags which
+	// precede the EtherType.
+	hl := 14
+	for {
+		b, err := r.Peek(hl)
+		if err != nil {
+			return nil, peekError(b, err)
+		}
+
+		if !EtherType(binary.BigEndian.Uint16(b[hl-2 : hl])).isVLANTPID() {
+			break
+		}
+
+		hl += 4
+	}
+
+	var n int
+	for size := hl; ; size++ {
+		header, err := r.Peek(size)
+		if err != nil {
+			return nil, peekError(header, err)
+		}
+
+		n, err = lengthOf(header)
+		if err == io.ErrShortBuffer {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		break
+	}
+
+	if n < hl {
+		return nil, fmt.Errorf("frame length %d is shorter than %d byte header", n, hl)
+	}
+
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+
+	f := new(Frame)
+	if err := f.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// DecodeStream unmarshals each byte slice received on in into a Frame, and
+// sends the Frame on out. If a byte slice cannot be unmarshaled, the error
+// returned by Frame.UnmarshalBinary is sent on errs instead, and decoding
+// continues. If errs is nil, such errors are discarded.
+//
+// DecodeStream is intended to be run in its own goroutine as a stage of a
+// concurrent pipeline. When in is closed, DecodeStream closes out and errs,
+// if it is not nil, and returns.
+//
+// Each Frame's fields are copied from its byte slice, and DecodeStream does
+// not retain a byte slice after sending its Frame or error, so the sender
+// may reuse a byte slice once DecodeStream has sent the result for it.
+func DecodeStream(in <-chan []byte, out chan<- *Frame, errs chan<- error) {
+	defer func() {
+		close(out)
+		if errs != nil {
+			close(errs)
+		}
+	}()
+
+	for b := range in {
+		f := new(Frame)
+		if err := f.UnmarshalBinary(b); err != nil {
+			if errs != nil {
+				errs <- err
+			}
+
+			continue
+		}
+
+		out <- f
+	}
+}
+
+// peekError converts an error from bufio.Reader.Peek into an error suitable
+// for ReadFrameFunc, where b is the data returned by Peek.
+func peekError(b []byte, err error) error {
+	if err != io.EOF {
+		return err
+	}
+
+	// Running out of data is only acceptable at the beginning of a Frame.
+	if len(b) == 0 {
+		return io.EOF
+	}
+
+	return io.ErrUnexpectedEOF
+}
@@ -0,0 +1,227 @@
+package ethernet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestReadFrameFunc(t *testing.T) {
+	// lengthOf decodes a 2 byte total frame length from the beginning of
+	// the payload.
+	lengthOf := func(header []byte) (int, error) {
+		// Skip any VLAN tags to find the EtherType.
+		n := 12
+		for EtherType(binary.BigEndian.Uint16(header[n:n+2])) == EtherTypeVLAN {
+			n += 4
+		}
+		if EtherType(binary.BigEndian.Uint16(header[n:n+2])) != 0xcccc {
+			return n + 2, nil
+		}
+
+		// Wait for 2 payload bytes to be available.
+		if len(header) < n+4 {
+			return 0, io.ErrShortBuffer
+		}
+
+		return int(binary.BigEndian.Uint16(header[n+2 : n+4])), nil
+	}
+
+	var tests = []struct {
+		desc string
+		b    []byte
+		fs   []*Frame
+		err  error
+	}{
+		{
+			desc: "empty stream",
+			err:  io.EOF,
+		},
+		{
+			desc: "short header",
+			b:    bytes.Repeat([]byte{0}, 13),
+			err:  io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "short VLAN",
+			b: []byte{
+				0, 1, 0, 1, 0, 1,
+				1, 0, 1, 0, 1, 0,
+				0x81, 0x00,
+				0x00,
+			},
+			err: io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "short length field",
+			b: []byte{
+				0, 1, 0, 1, 0, 1,
+				1, 0, 1, 0, 1, 0,
+				0xcc, 0xcc,
+				0x00,
+			},
+			err: io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "short payload",
+			b: []byte{
+				0, 1, 0, 1, 0, 1,
+				1, 0, 1, 0, 1, 0,
+				0xcc, 0xcc,
+				0x00, 0x12,
+				0xde,
+			},
+			err: io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "two frames, one VLAN",
+			b: []byte{
+				0, 1, 0, 1, 0, 1,
+				1, 0, 1, 0, 1, 0,
+				0xcc, 0xcc,
+				0x00, 0x12,
+				0xde, 0xad,
+
+				1, 0, 1, 0, 1, 0,
+				0, 1, 0, 1, 0, 1,
+				0x81, 0x00,
+				0x20, 0x65,
+				0xcc, 0xcc,
+				0x00, 0x17,
+				0xbe, 0xef, 0x00,
+			},
+			fs: []*Frame{
+				{
+					Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+					Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+					EtherType:   0xcccc,
+					Payload:     []byte{0x00, 0x12, 0xde, 0xad},
+				},
+				{
+					Destination: net.HardwareAddr{1, 0, 1, 0, 1, 0},
+					Source:      net.HardwareAddr{0, 1, 0, 1, 0, 1},
+					VLAN: []*VLAN{{
+						Priority: 1,
+						ID:       101,
+					}},
+					EtherType: 0xcccc,
+					Payload:   []byte{0x00, 0x17, 0xbe, 0xef, 0x00},
+				},
+			},
+			err: io.EOF,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			r := bufio.NewReader(bytes.NewReader(tt.b))
+
+			var fs []*Frame
+			var err error
+			for {
+				var f *Frame
+				f, err = ReadFrameFunc(r, lengthOf)
+				if err != nil {
+					break
+				}
+
+				fs = append(fs, f)
+			}
+
+			if want, got := tt.err, err; want != got {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.fs, fs; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected Frames:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestDecodeStream(t *testing.T) {
+	in := make(chan []byte)
+	out := make(chan *Frame)
+	errs := make(chan error)
+
+	go DecodeStream(in, out, errs)
+
+	go func() {
+		defer close(in)
+		for _, tt := range TestVectors {
+			if tt.FCS {
+				continue
+			}
+
+			in <- tt.Bytes
+			in <- tt.Bytes[:13]
+		}
+	}()
+
+	var fs []*Frame
+	var nErrs int
+	for out != nil || errs != nil {
+		select {
+		case f, ok := <-out:
+			if !ok {
+				out = nil
+				continue
+			}
+
+			fs = append(fs, f)
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+
+			if err != io.ErrUnexpectedEOF {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			nErrs++
+		}
+	}
+
+	if want, got := nErrs, len(fs); want != got {
+		t.Fatalf("unexpected number of Frames and errors: %v != %v", want, got)
+	}
+
+	var i int
+	for _, tt := range TestVectors {
+		if tt.FCS {
+			continue
+		}
+
+		if want, got := tt.Frame, fs[i]; !reflect.DeepEqual(want, got) {
+			t.Fatalf("[%02d] test %q, unexpected Frame:\n- want: %v\n- got: %v",
+				i, tt.Name, want, got)
+		}
+		i++
+	}
+}
+
+func TestDecodeStreamNilErrors(t *testing.T) {
+	in := make(chan []byte, 2)
+	out := make(chan *Frame, 2)
+
+	in <- []byte{0x00}
+	in <- TestVectors[0].Bytes
+	close(in)
+
+	DecodeStream(in, out, nil)
+
+	var fs []*Frame
+	for f := range out {
+		fs = append(fs, f)
+	}
+
+	if want, got := []*Frame{TestVectors[0].Frame}, fs; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected Frames:\n- want: %v\n- got: %v", want, got)
+	}
+}
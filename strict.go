@@ -0,0 +1,136 @@
+package ethernet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	// ErrInvalidDestination is returned by Frame.MarshalStrict when a Frame's
+	// destination hardware address is missing or all zeros.
+	ErrInvalidDestination = errors.New("invalid destination hardware address")
+
+	// ErrLoopback is returned by Frame.MarshalStrict when a Frame's source
+	// and destination hardware addresses are identical.
+	ErrLoopback = errors.New("source and destination hardware addresses are identical")
+
+	// ErrPayloadTooShort is returned by Frame.MarshalStrict when a Frame's
+	// payload is shorter than the minimum size, and would be padded.
+	ErrPayloadTooShort = errors.New("payload shorter than minimum size")
+
+	// ErrInvalidQinQ is returned by Frame.ValidateQinQ when a Frame's stack
+	// of VLAN tags uses tag protocol identifiers incorrectly.
+	ErrInvalidQinQ = errors.New("invalid Q-in-Q tag stack")
+
+	// ErrIPVersionMismatch is returned by Frame.CheckIPConsistency when a
+	// Frame's EtherType and the version of its IP payload disagree.
+	ErrIPVersionMismatch = errors.New("EtherType does not match IP version")
+)
+
+// MarshalStrict allocates a byte slice and marshals a Frame into binary form,
+// like MarshalBinary, but first performs additional validation which is
+// useful for catching mistakes in generated traffic.
+//
+// If the Frame does not have a valid destination hardware address, as
+// reported by HasValidDestination, ErrInvalidDestination is returned. If
+// the Frame is a loopback Frame, as reported by IsLoopback, ErrLoopback is
+// returned.
+//
+// If the Frame's EtherType is in the undefined range of 1501 to 1535, or is
+// 0 and LengthField is not set, the EtherType is ambiguous, and
+// ErrInvalidEtherType is returned. The UndefinedRange policy, which applies
+// only when a Frame is unmarshaled, is not consulted.
+//
+// If the Frame's payload is shorter than the minimum payload size, or than
+// required to reach MinSize, ErrPayloadTooShort is returned rather than
+// silently padding the payload, so that the caller is aware of the padding
+// decision. Pad the payload explicitly, or set NoPad to marshal the Frame
+// without padding.
+//
+// MarshalStrict otherwise returns the same errors as MarshalBinary.
+func (f *Frame) MarshalStrict() ([]byte, error) {
+	if err := f.validateStrict(); err != nil {
+		return nil, err
+	}
+
+	return f.MarshalBinary()
+}
+
+// validateStrict performs the additional validation done by MarshalStrict.
+func (f *Frame) validateStrict() error {
+	if !f.HasValidDestination() {
+		return ErrInvalidDestination
+	}
+	if f.IsLoopback() {
+		return ErrLoopback
+	}
+	if f.EtherType.undefined() || (f.EtherType == 0 && !f.LengthField) {
+		return ErrInvalidEtherType
+	}
+	if len(f.Payload) < f.minPayload() {
+		return ErrPayloadTooShort
+	}
+
+	return nil
+}
+
+// ValidateQinQ performs strict validation of the tag protocol identifiers of
+// a Frame's stacked VLAN tags, as used by provider networks. The outermost
+// tag may use any tag protocol identifier in VLANTPIDs, such as
+// EtherTypeServiceVLAN or EtherTypeQinQ, but every inner tag must use the
+// standard EtherTypeVLAN identifier. A Frame with zero or one VLAN tags is
+// always valid.
+//
+// Unmarshaling a Frame is permissive, so ValidateQinQ must be called
+// explicitly. If a tag violates these rules, an error wrapping
+// ErrInvalidQinQ is returned which identifies the tag.
+func (f *Frame) ValidateQinQ() error {
+	if len(f.VLAN) == 0 {
+		return nil
+	}
+
+	if tpid := f.VLAN[0].tpid(); !tpid.isVLANTPID() {
+		return fmt.Errorf("%w: outer tag has unrecognized TPID %#04x",
+			ErrInvalidQinQ, uint16(tpid))
+	}
+
+	for i, v := range f.VLAN[1:] {
+		if tpid := v.tpid(); tpid != EtherTypeVLAN {
+			return fmt.Errorf("%w: inner tag %d has TPID %#04x, but must have TPID %#04x",
+				ErrInvalidQinQ, i+1, uint16(tpid), uint16(EtherTypeVLAN))
+		}
+	}
+
+	return nil
+}
+
+// CheckIPConsistency verifies that the version in the first 4 bits of the
+// payload of a Frame with EtherType EtherTypeIPv4 or EtherTypeIPv6 matches
+// its EtherType, which catches construction bugs in traffic generators. For
+// all other EtherTypes, CheckIPConsistency does nothing and returns nil.
+//
+// If the versions disagree, an error wrapping ErrIPVersionMismatch is
+// returned. If the payload is empty, io.ErrUnexpectedEOF is returned.
+func (f *Frame) CheckIPConsistency() error {
+	var want byte
+	switch f.EtherType {
+	case EtherTypeIPv4:
+		want = 4
+	case EtherTypeIPv6:
+		want = 6
+	default:
+		return nil
+	}
+
+	if len(f.Payload) == 0 {
+		return io.ErrUnexpectedEOF
+	}
+
+	if got := f.Payload[0] >> 4; got != want {
+		return fmt.Errorf("%w: EtherType %#04x, but IP version %d",
+			ErrIPVersionMismatch, uint16(f.EtherType), got)
+	}
+
+	return nil
+}
@@ -0,0 +1,348 @@
+package ethernet
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"testing"
+)
+
+func TestFrameMarshalStrict(t *testing.T) {
+	var tests = []struct {
+		desc string
+		f    *Frame
+		err  error
+	}{
+		{
+			desc: "zero destination",
+			f: &Frame{
+				Destination: net.HardwareAddr{0, 0, 0, 0, 0, 0},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				EtherType:   EtherTypeIPv4,
+				Payload:     bytes.Repeat([]byte{0}, 50),
+			},
+			err: ErrInvalidDestination,
+		},
+		{
+			desc: "loopback",
+			f: &Frame{
+				Destination: net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				EtherType:   EtherTypeIPv4,
+				Payload:     bytes.Repeat([]byte{0}, 50),
+			},
+			err: ErrLoopback,
+		},
+		{
+			desc: "zero EtherType",
+			f: &Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				Payload:     bytes.Repeat([]byte{0}, 50),
+			},
+			err: ErrInvalidEtherType,
+		},
+		{
+			desc: "undefined EtherType",
+			f: &Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				EtherType:   1501,
+				Payload:     bytes.Repeat([]byte{0}, 50),
+			},
+			err: ErrInvalidEtherType,
+		},
+		{
+			desc: "IEEE 802.3 length",
+			f: &Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				EtherType:   50,
+				Payload:     bytes.Repeat([]byte{0}, 50),
+			},
+		},
+		{
+			desc: "VLAN ID too large",
+			f: &Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				VLAN: []*VLAN{{
+					ID: VLANMax,
+				}},
+				EtherType: EtherTypeIPv4,
+				Payload:   bytes.Repeat([]byte{0}, 50),
+			},
+			err: ErrInvalidVLAN,
+		},
+		{
+			desc: "payload too short",
+			f: &Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				EtherType:   EtherTypeIPv4,
+				Payload:     bytes.Repeat([]byte{0}, MinPayload-1),
+			},
+			err: ErrPayloadTooShort,
+		},
+		{
+			desc: "payload too short for MinSize",
+			f: &Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				EtherType:   EtherTypeIPv4,
+				Payload:     bytes.Repeat([]byte{0}, 50),
+				MinSize:     100,
+			},
+			err: ErrPayloadTooShort,
+		},
+		{
+			desc: "payload too short, NoPad",
+			f: &Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				EtherType:   EtherTypeIPv4,
+				Payload:     []byte{1, 2, 3},
+				NoPad:       true,
+			},
+		},
+		{
+			desc: "minimum payload",
+			f: &Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				EtherType:   EtherTypeIPv4,
+				Payload:     bytes.Repeat([]byte{0}, MinPayload),
+			},
+		},
+		{
+			desc: "OK",
+			f: &Frame{
+				Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+				Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+				EtherType:   EtherTypeIPv4,
+				Payload:     bytes.Repeat([]byte{0}, 50),
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			b, err := tt.f.MarshalStrict()
+			if want, got := tt.err, err; want != got {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+			if err != nil {
+				return
+			}
+
+			// A valid Frame must marshal identically to MarshalBinary.
+			want, err := tt.f.MarshalBinary()
+			if err != nil {
+				t.Fatalf("[%02d] test %q, failed to marshal Frame: %v",
+					i, tt.desc, err)
+			}
+
+			if got := b; !bytes.Equal(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected Frame bytes:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameMarshalStrictZeroLength(t *testing.T) {
+	// NoPad permits the empty payload, which would otherwise be padded.
+	f := &Frame{
+		Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+		Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+		NoPad:       true,
+	}
+
+	if _, err := f.MarshalStrict(); err != ErrInvalidEtherType {
+		t.Fatalf("unexpected error: %v != %v", ErrInvalidEtherType, err)
+	}
+
+	// A zero EtherType is permitted as an IEEE 802.3 length of 0 only when
+	// the field is explicitly treated as a length.
+	f.LengthField = true
+
+	b, err := f.MarshalStrict()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want, got := 14, len(b); want != got {
+		t.Fatalf("unexpected Frame length: %v != %v", want, got)
+	}
+}
+
+func TestFrameValidateQinQ(t *testing.T) {
+	var tests = []struct {
+		desc string
+		vlan []*VLAN
+		msg  string
+	}{
+		{
+			desc: "no VLANs",
+		},
+		{
+			desc: "802.1Q",
+			vlan: []*VLAN{{ID: 10}},
+		},
+		{
+			desc: "802.1ad, single tag",
+			vlan: []*VLAN{{TPID: EtherTypeServiceVLAN, ID: 10}},
+		},
+		{
+			desc: "802.1ad",
+			vlan: []*VLAN{
+				{TPID: EtherTypeServiceVLAN, ID: 10},
+				{ID: 20},
+			},
+		},
+		{
+			desc: "legacy Q-in-Q",
+			vlan: []*VLAN{
+				{TPID: EtherTypeQinQ, ID: 10},
+				{TPID: EtherTypeVLAN, ID: 20},
+			},
+		},
+		{
+			desc: "802.1Q outer, 802.1Q inner",
+			vlan: []*VLAN{
+				{ID: 10},
+				{ID: 20},
+			},
+		},
+		{
+			desc: "unrecognized outer TPID",
+			vlan: []*VLAN{
+				{TPID: EtherTypeIPv4, ID: 10},
+				{ID: 20},
+			},
+			msg: "invalid Q-in-Q tag stack: outer tag has unrecognized TPID 0x0800",
+		},
+		{
+			desc: "802.1ad inner",
+			vlan: []*VLAN{
+				{TPID: EtherTypeServiceVLAN, ID: 10},
+				{TPID: EtherTypeServiceVLAN, ID: 20},
+			},
+			msg: "invalid Q-in-Q tag stack: inner tag 1 has TPID 0x88a8, but must have TPID 0x8100",
+		},
+		{
+			desc: "legacy Q-in-Q innermost",
+			vlan: []*VLAN{
+				{TPID: EtherTypeServiceVLAN, ID: 10},
+				{ID: 20},
+				{TPID: EtherTypeQinQ, ID: 30},
+			},
+			msg: "invalid Q-in-Q tag stack: inner tag 2 has TPID 0x9100, but must have TPID 0x8100",
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := (&Frame{VLAN: tt.vlan}).ValidateQinQ()
+			if tt.msg == "" {
+				if err != nil {
+					t.Fatalf("[%02d] test %q, unexpected error: %v",
+						i, tt.desc, err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, ErrInvalidQinQ) {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, ErrInvalidQinQ, err)
+			}
+			if want, got := tt.msg, err.Error(); want != got {
+				t.Fatalf("[%02d] test %q, unexpected error message:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameCheckIPConsistency(t *testing.T) {
+	var tests = []struct {
+		desc string
+		et   EtherType
+		p    []byte
+		msg  string
+		err  error
+	}{
+		{
+			desc: "ARP",
+			et:   EtherTypeARP,
+			p:    []byte{0x60},
+		},
+		{
+			desc: "ARP, empty payload",
+			et:   EtherTypeARP,
+		},
+		{
+			desc: "IPv4, empty payload",
+			et:   EtherTypeIPv4,
+			err:  io.ErrUnexpectedEOF,
+		},
+		{
+			desc: "IPv4",
+			et:   EtherTypeIPv4,
+			p:    []byte{0x45, 0x00},
+		},
+		{
+			desc: "IPv6",
+			et:   EtherTypeIPv6,
+			p:    []byte{0x60, 0x00},
+		},
+		{
+			desc: "IPv4 EtherType, IPv6 payload",
+			et:   EtherTypeIPv4,
+			p:    []byte{0x60, 0x00},
+			msg:  "EtherType does not match IP version: EtherType 0x0800, but IP version 6",
+			err:  ErrIPVersionMismatch,
+		},
+		{
+			desc: "IPv6 EtherType, IPv4 payload",
+			et:   EtherTypeIPv6,
+			p:    []byte{0x45, 0x00},
+			msg:  "EtherType does not match IP version: EtherType 0x86dd, but IP version 4",
+			err:  ErrIPVersionMismatch,
+		},
+		{
+			desc: "IPv4 EtherType, zero payload",
+			et:   EtherTypeIPv4,
+			p:    []byte{0x00},
+			msg:  "EtherType does not match IP version: EtherType 0x0800, but IP version 0",
+			err:  ErrIPVersionMismatch,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f := &Frame{
+				EtherType: tt.et,
+				Payload:   tt.p,
+			}
+
+			err := f.CheckIPConsistency()
+			if want, got := tt.err, err; !errors.Is(got, want) || (want == nil) != (got == nil) {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if tt.msg == "" {
+				return
+			}
+
+			if want, got := tt.msg, err.Error(); want != got {
+				t.Fatalf("[%02d] test %q, unexpected error message:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
@@ -10,28 +10,42 @@ func _() {
 	var x [1]struct{}
 	_ = x[EtherTypeIPv4-2048]
 	_ = x[EtherTypeARP-2054]
+	_ = x[EtherTypeTransparentBridging-25944]
 	_ = x[EtherTypeVLAN-33024]
 	_ = x[EtherTypeIPv6-34525]
+	_ = x[EtherTypeMACControl-34824]
+	_ = x[EtherTypeSlowProtocols-34825]
+	_ = x[EtherTypeServiceVLAN-34984]
+	_ = x[EtherTypeLocalExperimental1-34997]
+	_ = x[EtherTypeLocalExperimental2-34998]
+	_ = x[EtherTypeLLDP-35020]
+	_ = x[EtherTypeITag-35047]
+	_ = x[EtherTypeQinQ-37120]
+	_ = x[EtherTypeQinQAlt-37376]
 }
 
-const (
-	_EtherType_name_0 = "EtherTypeIPv4"
-	_EtherType_name_1 = "EtherTypeARP"
-	_EtherType_name_2 = "EtherTypeVLAN"
-	_EtherType_name_3 = "EtherTypeIPv6"
-)
+const _EtherType_name = "EtherTypeIPv4EtherTypeARPEtherTypeTransparentBridgingEtherTypeVLANEtherTypeIPv6EtherTypeMACControlEtherTypeSlowProtocolsEtherTypeServiceVLANEtherTypeLocalExperimental1EtherTypeLocalExperimental2EtherTypeLLDPEtherTypeITagEtherTypeQinQEtherTypeQinQAlt"
+
+var _EtherType_map = map[EtherType]string{
+	2048:  _EtherType_name[0:13],
+	2054:  _EtherType_name[13:25],
+	25944: _EtherType_name[25:53],
+	33024: _EtherType_name[53:66],
+	34525: _EtherType_name[66:79],
+	34824: _EtherType_name[79:98],
+	34825: _EtherType_name[98:120],
+	34984: _EtherType_name[120:140],
+	34997: _EtherType_name[140:167],
+	34998: _EtherType_name[167:194],
+	35020: _EtherType_name[194:207],
+	35047: _EtherType_name[207:220],
+	37120: _EtherType_name[220:233],
+	37376: _EtherType_name[233:249],
+}
 
 func (i EtherType) String() string {
-	switch {
-	case i == 2048:
-		return _EtherType_name_0
-	case i == 2054:
-		return _EtherType_name_1
-	case i == 33024:
-		return _EtherType_name_2
-	case i == 34525:
-		return _EtherType_name_3
-	default:
-		return "EtherType(" + strconv.FormatInt(int64(i), 10) + ")"
+	if str, ok := _EtherType_map[i]; ok {
+		return str
 	}
+	return "EtherType(" + strconv.FormatInt(int64(i), 10) + ")"
 }
@@ -0,0 +1,137 @@
+package ethernet
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A Tag is a VLAN tag, represented as a value rather than a pointer. Tags are
+// comparable, so they may be compared with == and used as map keys, which is
+// useful for policy matching.
+type Tag struct {
+	// TPID is the tag protocol identifier of the tag, such as EtherTypeVLAN
+	// or EtherTypeServiceVLAN. TPID is never 0 in a TagStack produced by
+	// Frame.TagStack.
+	TPID EtherType
+
+	// ID is the VLAN ID of the tag.
+	ID uint16
+
+	// PCP is the IEEE 802.1p priority code point of the tag.
+	PCP uint8
+
+	// DEI is the drop eligible indicator of the tag.
+	DEI bool
+}
+
+// String returns a human readable representation of a Tag, such as
+// "TPID 0x8100, ID 10, PCP 5, DEI false".
+func (t Tag) String() string {
+	return fmt.Sprintf("TPID %#04x, ID %d, PCP %d, DEI %t",
+		uint16(t.TPID), t.ID, t.PCP, t.DEI)
+}
+
+// A TagStack is the stack of VLAN tags carried by a Frame, ordered from
+// outermost to innermost. A TagStack has value semantics: unlike a Frame's
+// VLAN field, it does not share any state with the Frame from which it was
+// produced.
+type TagStack []Tag
+
+// TagStack returns the VLAN tags carried by a Frame as a TagStack. The TPID
+// of each Tag is the tag protocol identifier used when the Frame is
+// marshaled, so a VLAN with a TPID of 0 produces a Tag with TPID
+// EtherTypeVLAN. If the Frame is untagged, TagStack returns nil.
+func (f *Frame) TagStack() TagStack {
+	if len(f.VLAN) == 0 {
+		return nil
+	}
+
+	s := make(TagStack, 0, len(f.VLAN))
+	for _, v := range f.VLAN {
+		s = append(s, Tag{
+			TPID: v.tpid(),
+			ID:   v.ID,
+			PCP:  uint8(v.Priority),
+			DEI:  v.DropEligible,
+		})
+	}
+
+	return s
+}
+
+// Equal reports whether s and o contain identical Tags in the same order.
+func (s TagStack) Equal(o TagStack) bool {
+	if len(s) != len(o) {
+		return false
+	}
+
+	for i := range s {
+		if s[i] != o[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FromTagStack returns a newly allocated VLAN for each Tag in s, suitable for
+// use as the VLAN field of a Frame, reversing the operation performed by
+// Frame.TagStack. A Tag with TPID EtherTypeVLAN or 0 produces a VLAN with a
+// TPID of 0. If s is empty, FromTagStack returns nil.
+//
+// FromTagStack does not validate the Tags; invalid values are reported when
+// the Frame is marshaled.
+func FromTagStack(s TagStack) []*VLAN {
+	if len(s) == 0 {
+		return nil
+	}
+
+	vlans := make([]*VLAN, 0, len(s))
+	for _, t := range s {
+		v := &VLAN{
+			Priority:     Priority(t.PCP),
+			DropEligible: t.DEI,
+			ID:           t.ID,
+		}
+		v.setTPID(t.TPID)
+
+		vlans = append(vlans, v)
+	}
+
+	return vlans
+}
+
+// DiffVLANs describes how the VLAN tags carried by f differ from those
+// carried by o, which is useful for verifying VLAN edit operations, such as
+// Q-in-Q translation. Tags are compared by position, from outermost to
+// innermost, as values in a TagStack, so a VLAN with a TPID of 0 is equal to
+// one with TPID EtherTypeVLAN.
+//
+// Each difference is described on its own line, as one of:
+//   - "changed tag N: <tag in f> -> <tag in o>"
+//   - "added tag N: <tag in o>", if o carries more tags than f
+//   - "removed tag N: <tag in f>", if o carries fewer tags than f
+//
+// If the tags are identical, DiffVLANs returns the empty string.
+func (f *Frame) DiffVLANs(o *Frame) string {
+	a, b := f.TagStack(), o.TagStack()
+
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+
+	var diffs []string
+	for i := 0; i < n; i++ {
+		switch {
+		case i >= len(a):
+			diffs = append(diffs, fmt.Sprintf("added tag %d: %s", i, b[i]))
+		case i >= len(b):
+			diffs = append(diffs, fmt.Sprintf("removed tag %d: %s", i, a[i]))
+		case a[i] != b[i]:
+			diffs = append(diffs, fmt.Sprintf("changed tag %d: %s -> %s", i, a[i], b[i]))
+		}
+	}
+
+	return strings.Join(diffs, "\n")
+}
@@ -0,0 +1,184 @@
+package ethernet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFrameTagStack(t *testing.T) {
+	var tests = []struct {
+		desc string
+		vlan []*VLAN
+		s    TagStack
+	}{
+		{
+			desc: "no VLANs",
+		},
+		{
+			desc: "802.1Q",
+			vlan: []*VLAN{{Priority: PriorityVoice, ID: 100}},
+			s: TagStack{
+				{TPID: EtherTypeVLAN, ID: 100, PCP: uint8(PriorityVoice)},
+			},
+		},
+		{
+			desc: "802.1ad",
+			vlan: []*VLAN{
+				{TPID: EtherTypeServiceVLAN, ID: 10, DropEligible: true},
+				{ID: 20},
+			},
+			s: TagStack{
+				{TPID: EtherTypeServiceVLAN, ID: 10, DEI: true},
+				{TPID: EtherTypeVLAN, ID: 20},
+			},
+		},
+		{
+			desc: "legacy Q-in-Q",
+			vlan: []*VLAN{
+				{TPID: EtherTypeQinQ, ID: 200},
+				{Priority: PriorityVideo, ID: 300},
+			},
+			s: TagStack{
+				{TPID: EtherTypeQinQ, ID: 200},
+				{TPID: EtherTypeVLAN, ID: 300, PCP: uint8(PriorityVideo)},
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f := &Frame{VLAN: tt.vlan}
+
+			s := f.TagStack()
+			if want, got := tt.s, s; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected TagStack:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.vlan, FromTagStack(s); !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected VLANs:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestTagStackEqual(t *testing.T) {
+	outer := Tag{TPID: EtherTypeServiceVLAN, ID: 10}
+	inner := Tag{TPID: EtherTypeVLAN, ID: 20}
+
+	var tests = []struct {
+		desc string
+		a, b TagStack
+		ok   bool
+	}{
+		{
+			desc: "empty",
+			ok:   true,
+		},
+		{
+			desc: "nil and empty",
+			a:    TagStack{},
+			ok:   true,
+		},
+		{
+			desc: "different lengths",
+			a:    TagStack{outer, inner},
+			b:    TagStack{outer},
+		},
+		{
+			desc: "different order",
+			a:    TagStack{outer, inner},
+			b:    TagStack{inner, outer},
+		},
+		{
+			desc: "different DEI",
+			a:    TagStack{outer},
+			b:    TagStack{{TPID: EtherTypeServiceVLAN, ID: 10, DEI: true}},
+		},
+		{
+			desc: "identical",
+			a:    TagStack{outer, inner},
+			b:    TagStack{outer, inner},
+			ok:   true,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if want, got := tt.ok, tt.a.Equal(tt.b); want != got {
+				t.Fatalf("[%02d] test %q, unexpected Equal: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestTagMapKey(t *testing.T) {
+	// Tags produced from separate Frames must match as map keys.
+	policy := map[Tag]string{
+		{TPID: EtherTypeVLAN, ID: 100}: "voice",
+	}
+
+	f := &Frame{VLAN: []*VLAN{{ID: 100}}}
+	if want, got := "voice", policy[f.TagStack()[0]]; want != got {
+		t.Fatalf("unexpected policy: %q != %q", want, got)
+	}
+}
+
+func TestFrameDiffVLANs(t *testing.T) {
+	var tests = []struct {
+		desc string
+		a, b []*VLAN
+		diff string
+	}{
+		{
+			desc: "untagged",
+		},
+		{
+			desc: "identical",
+			a:    []*VLAN{{TPID: EtherTypeServiceVLAN, ID: 10}, {ID: 20}},
+			b:    []*VLAN{{TPID: EtherTypeServiceVLAN, ID: 10}, {ID: 20}},
+		},
+		{
+			desc: "implicit and explicit 802.1Q TPID",
+			a:    []*VLAN{{ID: 10}},
+			b:    []*VLAN{{TPID: EtherTypeVLAN, ID: 10}},
+		},
+		{
+			desc: "added",
+			a:    []*VLAN{{ID: 20}},
+			b:    []*VLAN{{ID: 20}, {ID: 30}},
+			diff: "added tag 1: TPID 0x8100, ID 30, PCP 0, DEI false",
+		},
+		{
+			desc: "removed",
+			a:    []*VLAN{{TPID: EtherTypeServiceVLAN, ID: 10}, {ID: 20}},
+			b:    []*VLAN{{TPID: EtherTypeServiceVLAN, ID: 10}},
+			diff: "removed tag 1: TPID 0x8100, ID 20, PCP 0, DEI false",
+		},
+		{
+			desc: "translated",
+			a:    []*VLAN{{TPID: EtherTypeServiceVLAN, ID: 10}, {ID: 20}},
+			b:    []*VLAN{{TPID: EtherTypeServiceVLAN, ID: 110, DropEligible: true}, {ID: 20}},
+			diff: "changed tag 0: TPID 0x88a8, ID 10, PCP 0, DEI false -> TPID 0x88a8, ID 110, PCP 0, DEI true",
+		},
+		{
+			desc: "pushed",
+			a:    []*VLAN{{ID: 20}},
+			b:    []*VLAN{{TPID: EtherTypeServiceVLAN, ID: 10, Priority: PriorityVoice}, {ID: 20}},
+			diff: "changed tag 0: TPID 0x8100, ID 20, PCP 0, DEI false -> TPID 0x88a8, ID 10, PCP 5, DEI false\n" +
+				"added tag 1: TPID 0x8100, ID 20, PCP 0, DEI false",
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			a, b := &Frame{VLAN: tt.a}, &Frame{VLAN: tt.b}
+			if want, got := tt.diff, a.DiffVLANs(b); want != got {
+				t.Fatalf("[%02d] test %q, unexpected diff:\n- want: %q\n- got: %q",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
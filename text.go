@@ -0,0 +1,205 @@
+package ethernet
+
+import (
+	"encoding"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidText is returned by Frame.UnmarshalText when text is not in
+	// the format produced by Frame.MarshalText.
+	ErrInvalidText = errors.New("invalid frame text")
+)
+
+// Compile-time assertions that Frame implements the text encoding
+// interfaces.
+var (
+	_ encoding.TextMarshaler   = (*Frame)(nil)
+	_ encoding.TextUnmarshaler = (*Frame)(nil)
+)
+
+// MarshalText implements encoding.TextMarshaler, producing a compact,
+// one-line representation of a Frame which is suitable for logs. The text is
+// the Frame's Summary, followed by ", payload " and the payload as lowercase
+// hexadecimal digits. For example:
+//
+//	00:16:3e:44:55:66 > ff:ff:ff:ff:ff:ff, ethertype ARP (0x0806), length 60, payload 0001
+//
+// If the Frame has a Trailer, it follows the payload as ", trailer " and
+// lowercase hexadecimal digits, so that it is not lost when the text is
+// unmarshaled:
+//
+//	00:16:3e:44:55:66 > ff:ff:ff:ff:ff:ff, ethertype ARP (0x0806), length 62, payload 0001, trailer aabb
+//
+// PadByte and Timestamp are not included. MarshalText never returns an
+// error.
+func (f *Frame) MarshalText() ([]byte, error) {
+	s := f.Summary() + ", payload " + hex.EncodeToString(f.Payload)
+	if len(f.Trailer) > 0 {
+		s += ", trailer " + hex.EncodeToString(f.Trailer)
+	}
+
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing text in the
+// format produced by MarshalText. The names of EtherTypes and the length are
+// ignored: each EtherType and VLAN tag protocol identifier is parsed from its
+// hexadecimal value.
+//
+// If text is malformed, an error wrapping ErrInvalidText is returned.
+func (f *Frame) UnmarshalText(text []byte) error {
+	s := string(text)
+
+	i := strings.LastIndex(s, ", payload")
+	if i == -1 {
+		return fmt.Errorf("%w: missing payload", ErrInvalidText)
+	}
+	body, trailerText := s[i+len(", payload"):], ""
+	if j := strings.Index(body, ", trailer"); j != -1 {
+		body, trailerText = body[:j], body[j+len(", trailer"):]
+	}
+
+	payload, err := hex.DecodeString(strings.TrimSpace(body))
+	if err != nil {
+		return fmt.Errorf("%w: payload: %v", ErrInvalidText, err)
+	}
+
+	var trailer []byte
+	if trailerText != "" {
+		trailer, err = hex.DecodeString(strings.TrimSpace(trailerText))
+		if err != nil {
+			return fmt.Errorf("%w: trailer: %v", ErrInvalidText, err)
+		}
+		if len(trailer) == 0 {
+			trailer = nil
+		}
+	}
+
+	// The header is followed by any VLAN tags after a colon and space,
+	// which cannot appear within a hardware address.
+	head, tags := s[:i], ""
+	if j := strings.Index(head, ": "); j != -1 {
+		head, tags = head[:j], head[j+2:]
+	}
+
+	fields := strings.Split(head, ", ")
+	if len(fields) != 3 || !strings.HasPrefix(fields[2], "length ") {
+		return fmt.Errorf("%w: malformed header %q", ErrInvalidText, head)
+	}
+
+	addrs := strings.Split(fields[0], " > ")
+	if len(addrs) != 2 {
+		return fmt.Errorf("%w: malformed addresses %q", ErrInvalidText, fields[0])
+	}
+	src, err := parseTextAddr(addrs[0])
+	if err != nil {
+		return err
+	}
+	dst, err := parseTextAddr(addrs[1])
+	if err != nil {
+		return err
+	}
+
+	et, err := parseTextEtherType(fields[1])
+	if err != nil {
+		return err
+	}
+
+	var vlans []*VLAN
+	if tags != "" {
+		// The first EtherType is the protocol identifier of the first tag,
+		// and the EtherType following each tag is that of the next tag, or
+		// of the Frame itself.
+		tpid := et
+		fields := strings.Split(tags, ", ")
+		for len(fields) > 0 {
+			v, n, err := parseTextVLAN(fields)
+			if err != nil {
+				return err
+			}
+			v.setTPID(tpid)
+			vlans = append(vlans, v)
+
+			if tpid, err = parseTextEtherType(fields[n]); err != nil {
+				return err
+			}
+			fields = fields[n+1:]
+		}
+
+		et = tpid
+	}
+
+	f.resetUnmarshaled()
+	f.Destination = dst
+	f.Source = src
+	f.VLAN = vlans
+	f.EtherType = et
+	f.Payload = payload
+	f.Trailer = trailer
+	return nil
+}
+
+// parseTextAddr parses a hardware address for Frame.UnmarshalText.
+func parseTextAddr(s string) (net.HardwareAddr, error) {
+	addr, err := net.ParseMAC(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed address %q", ErrInvalidText, s)
+	}
+
+	return addr, nil
+}
+
+// parseTextEtherType parses an EtherType field, such as
+// "ethertype IPv4 (0x0800)", for Frame.UnmarshalText.
+func parseTextEtherType(s string) (EtherType, error) {
+	i := strings.LastIndex(s, "(0x")
+	if !strings.HasPrefix(s, "ethertype ") || i == -1 || !strings.HasSuffix(s, ")") {
+		return 0, fmt.Errorf("%w: malformed EtherType %q", ErrInvalidText, s)
+	}
+
+	v, err := strconv.ParseUint(s[i+3:len(s)-1], 16, 16)
+	if err != nil {
+		return 0, fmt.Errorf("%w: malformed EtherType %q", ErrInvalidText, s)
+	}
+
+	return EtherType(v), nil
+}
+
+// parseTextVLAN parses a VLAN tag, such as "vlan 100", "p 5", and an optional
+// "DEI", from the beginning of fields for Frame.UnmarshalText, and returns
+// the number of fields consumed. At least one field must follow the tag.
+func parseTextVLAN(fields []string) (*VLAN, int, error) {
+	if len(fields) < 3 {
+		return nil, 0, fmt.Errorf("%w: truncated VLAN %q", ErrInvalidText, strings.Join(fields, ", "))
+	}
+
+	id, err1 := strconv.ParseUint(strings.TrimPrefix(fields[0], "vlan "), 10, 12)
+	pri, err2 := strconv.ParseUint(strings.TrimPrefix(fields[1], "p "), 10, 3)
+	if err1 != nil || err2 != nil || id >= VLANMax ||
+		!strings.HasPrefix(fields[0], "vlan ") || !strings.HasPrefix(fields[1], "p ") {
+		return nil, 0, fmt.Errorf("%w: malformed VLAN %q", ErrInvalidText, fields[0]+", "+fields[1])
+	}
+
+	v := &VLAN{
+		Priority: Priority(pri),
+		ID:       uint16(id),
+	}
+
+	n := 2
+	if fields[2] == "DEI" {
+		v.DropEligible = true
+		n++
+	}
+
+	if n >= len(fields) {
+		return nil, 0, fmt.Errorf("%w: missing EtherType after VLAN", ErrInvalidText)
+	}
+
+	return v, n, nil
+}
@@ -0,0 +1,210 @@
+package ethernet
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestFrameMarshalText(t *testing.T) {
+	f := &Frame{
+		Destination: Broadcast,
+		Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+		VLAN: []*VLAN{{
+			Priority:     PriorityVoice,
+			DropEligible: true,
+			ID:           100,
+		}},
+		EtherType: EtherTypeARP,
+		Payload:   []byte{0xde, 0xad, 0xbe, 0xef},
+	}
+
+	b, err := f.MarshalText()
+	if err != nil {
+		t.Fatalf("failed to marshal text: %v", err)
+	}
+
+	want := "00:16:3e:44:55:66 > ff:ff:ff:ff:ff:ff, ethertype 802.1Q (0x8100), length 64: vlan 100, p 5, DEI, ethertype ARP (0x0806), payload deadbeef"
+	if got := string(b); want != got {
+		t.Fatalf("unexpected text:\n- want: %s\n- got: %s", want, got)
+	}
+
+	// A Trailer follows the payload.
+	f.Trailer = []byte{0xaa, 0xbb}
+	b, err = f.MarshalText()
+	if err != nil {
+		t.Fatalf("failed to marshal text: %v", err)
+	}
+
+	want = "00:16:3e:44:55:66 > ff:ff:ff:ff:ff:ff, ethertype 802.1Q (0x8100), length 66: vlan 100, p 5, DEI, ethertype ARP (0x0806), payload deadbeef, trailer aabb"
+	if got := string(b); want != got {
+		t.Fatalf("unexpected text with trailer:\n- want: %s\n- got: %s", want, got)
+	}
+}
+
+func TestFrameUnmarshalText(t *testing.T) {
+	var tests = []struct {
+		desc string
+		s    string
+		f    *Frame
+		err  error
+	}{
+		{
+			desc: "empty",
+			err:  ErrInvalidText,
+		},
+		{
+			desc: "bad payload",
+			s:    "00:16:3e:44:55:66 > ff:ff:ff:ff:ff:ff, ethertype ARP (0x0806), length 60, payload zz",
+			err:  ErrInvalidText,
+		},
+		{
+			desc: "bad trailer",
+			s:    "00:16:3e:44:55:66 > ff:ff:ff:ff:ff:ff, ethertype ARP (0x0806), length 60, payload 00, trailer zz",
+			err:  ErrInvalidText,
+		},
+		{
+			desc: "missing length",
+			s:    "00:16:3e:44:55:66 > ff:ff:ff:ff:ff:ff, ethertype ARP (0x0806), payload ",
+			err:  ErrInvalidText,
+		},
+		{
+			desc: "bad address",
+			s:    "00:16:3e:44:55 > ff:ff:ff:ff:ff:ff, ethertype ARP (0x0806), length 60, payload ",
+			err:  ErrInvalidText,
+		},
+		{
+			desc: "bad EtherType",
+			s:    "00:16:3e:44:55:66 > ff:ff:ff:ff:ff:ff, ethertype ARP (0806), length 60, payload ",
+			err:  ErrInvalidText,
+		},
+		{
+			desc: "VLAN ID too large",
+			s:    "00:16:3e:44:55:66 > ff:ff:ff:ff:ff:ff, ethertype 802.1Q (0x8100), length 64: vlan 4095, p 0, ethertype ARP (0x0806), payload ",
+			err:  ErrInvalidText,
+		},
+		{
+			desc: "VLAN missing EtherType",
+			s:    "00:16:3e:44:55:66 > ff:ff:ff:ff:ff:ff, ethertype 802.1Q (0x8100), length 64: vlan 10, p 0, DEI, payload ",
+			err:  ErrInvalidText,
+		},
+		{
+			desc: "OK, empty payload",
+			s:    "00:16:3e:44:55:66 > ff:ff:ff:ff:ff:ff, ethertype Unknown (0x9999), length 60, payload ",
+			f: &Frame{
+				Destination: Broadcast,
+				Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+				EtherType:   0x9999,
+				Payload:     []byte{},
+			},
+		},
+		{
+			desc: "OK, trailer",
+			s:    "00:16:3e:44:55:66 > ff:ff:ff:ff:ff:ff, ethertype ARP (0x0806), length 62, payload 0001, trailer aabb",
+			f: &Frame{
+				Destination: Broadcast,
+				Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+				EtherType:   EtherTypeARP,
+				Payload:     []byte{0x00, 0x01},
+				Trailer:     []byte{0xaa, 0xbb},
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f := new(Frame)
+			err := f.UnmarshalText([]byte(tt.s))
+			if want, got := tt.err, err; !errors.Is(got, want) {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+			if err != nil {
+				return
+			}
+
+			if want, got := tt.f, f; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected Frame:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameTextRoundTrip(t *testing.T) {
+	for i, tt := range TestVectors {
+		t.Run(tt.Name, func(t *testing.T) {
+			b, err := tt.Frame.MarshalText()
+			if err != nil {
+				t.Fatalf("[%02d] test %q, failed to marshal text: %v",
+					i, tt.Name, err)
+			}
+
+			f := new(Frame)
+			if err := f.UnmarshalText(b); err != nil {
+				t.Fatalf("[%02d] test %q, failed to unmarshal text: %v",
+					i, tt.Name, err)
+			}
+
+			if want, got := tt.Frame, f; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected Frame:\n- want: %v\n- got: %v",
+					i, tt.Name, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameUnmarshalTextReuseFrame(t *testing.T) {
+	v := TestVectors[2]
+	b, err := v.Frame.MarshalText()
+	if err != nil {
+		t.Fatalf("failed to marshal text: %v", err)
+	}
+
+	// Fields set by a Decoder must not persist when a Frame is reused.
+	f := &Frame{
+		Trailer:       []byte{0xaa, 0xbb},
+		Raw:           []byte{0xcc, 0xdd},
+		TagsTruncated: true,
+	}
+	if err := f.UnmarshalText(b); err != nil {
+		t.Fatalf("failed to unmarshal text: %v", err)
+	}
+
+	if want, got := v.Frame, f; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected Frame:\n- want: %v\n- got: %v", want, got)
+	}
+}
+
+func TestFrameTextRoundTripTrailer(t *testing.T) {
+	f := TestVectors[2].Frame.Clone()
+	f.Trailer = []byte{0xaa, 0xbb, 0xcc, 0xdd}
+
+	b, err := f.MarshalText()
+	if err != nil {
+		t.Fatalf("failed to marshal text: %v", err)
+	}
+
+	rf := new(Frame)
+	if err := rf.UnmarshalText(b); err != nil {
+		t.Fatalf("failed to unmarshal text: %v", err)
+	}
+
+	if !f.Equal(rf) {
+		t.Fatalf("unexpected Frame:\n- want: %v\n- got: %v", f, rf)
+	}
+
+	// The text form must marshal to the same binary form as the original.
+	want, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+	got, err := rf.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected Frame bytes:\n- want: %v\n- got: %v", want, got)
+	}
+}
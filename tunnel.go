@@ -0,0 +1,111 @@
+package ethernet
+
+import (
+	"errors"
+)
+
+// MaxTunnelDepth is the maximum number of nested layers of encapsulation
+// which are unwrapped by Frame.InnerFrame.
+const MaxTunnelDepth = 8
+
+var (
+	// ErrNotEncapsulated is returned when a Frame's payload does not contain
+	// an encapsulated Ethernet frame.
+	ErrNotEncapsulated = errors.New("payload is not an encapsulated Ethernet frame")
+)
+
+// EncapsulateFrame marshals inner into binary form, and sets it as the
+// payload of a Frame, with EtherType EtherTypeTransparentBridging. This
+// is useful for MAC-in-MAC and other Ethernet tunneling protocols.
+//
+// If either Frame contains invalid VLANs, ErrInvalidVLAN is returned.
+func (f *Frame) EncapsulateFrame(inner *Frame) error {
+	if err := f.validateVLANs(); err != nil {
+		return err
+	}
+
+	b, err := inner.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	f.EtherType = EtherTypeTransparentBridging
+	f.Payload = b
+	return nil
+}
+
+// DecapsulateFrame unmarshals the payload of a Frame with EtherType
+// EtherTypeTransparentBridging into a new Frame, reversing the operation
+// performed by EncapsulateFrame.
+//
+// If the Frame does not have EtherType EtherTypeTransparentBridging,
+// ErrNotEncapsulated is returned. Otherwise, DecapsulateFrame returns the
+// same errors as UnmarshalBinary.
+func (f *Frame) DecapsulateFrame() (*Frame, error) {
+	if f.EtherType != EtherTypeTransparentBridging {
+		return nil, ErrNotEncapsulated
+	}
+
+	if err := f.validateVLANs(); err != nil {
+		return nil, err
+	}
+
+	inner := new(Frame)
+	if err := inner.UnmarshalBinary(f.Payload); err != nil {
+		return nil, err
+	}
+
+	return inner, nil
+}
+
+// InnerFrame unwraps each layer of encapsulation from a Frame, as performed
+// by DecapsulateFrame for EtherType EtherTypeTransparentBridging, and by
+// DecapsulateITag for EtherType EtherTypeITag, and returns the innermost
+// Frame and true. This is useful for diagnosing deeply nested tunnels.
+//
+// InnerFrame returns false if the Frame does not encapsulate an Ethernet
+// frame, or if any layer cannot be decapsulated. To bound the work done for
+// crafted input, InnerFrame also returns false if more than MaxTunnelDepth
+// layers are nested.
+func (f *Frame) InnerFrame() (*Frame, bool) {
+	cur := f
+	for depth := 0; ; depth++ {
+		if cur.EtherType != EtherTypeTransparentBridging && cur.EtherType != EtherTypeITag {
+			if depth == 0 {
+				return nil, false
+			}
+
+			return cur, true
+		}
+		if depth == MaxTunnelDepth {
+			return nil, false
+		}
+
+		var (
+			inner *Frame
+			err   error
+		)
+		if cur.EtherType == EtherTypeITag {
+			_, inner, err = cur.DecapsulateITag()
+		} else {
+			inner, err = cur.DecapsulateFrame()
+		}
+		if err != nil {
+			return nil, false
+		}
+
+		cur = inner
+	}
+}
+
+// validateVLANs verifies that each of a Frame's VLANs could be marshaled.
+func (f *Frame) validateVLANs() error {
+	var b [2]byte
+	for _, v := range f.VLAN {
+		if _, err := v.read(b[:]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
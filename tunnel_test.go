@@ -0,0 +1,196 @@
+package ethernet
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestFrameEncapsulateFrame(t *testing.T) {
+	inner := &Frame{
+		Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+		Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+		VLAN: []*VLAN{{
+			Priority: 1,
+			ID:       101,
+		}},
+		EtherType: EtherTypeIPv4,
+		Payload:   bytes.Repeat([]byte{0xff}, 50),
+	}
+
+	outer := &Frame{
+		Destination: net.HardwareAddr{0, 2, 0, 2, 0, 2},
+		Source:      net.HardwareAddr{2, 0, 2, 0, 2, 0},
+		VLAN: []*VLAN{{
+			ID: 200,
+		}},
+	}
+
+	if err := outer.EncapsulateFrame(inner); err != nil {
+		t.Fatalf("failed to encapsulate Frame: %v", err)
+	}
+
+	if want, got := EtherTypeTransparentBridging, outer.EtherType; want != got {
+		t.Fatalf("unexpected EtherType: %v != %v", want, got)
+	}
+
+	// The encapsulated frame must survive a round trip through the outer
+	// frame's binary form.
+	b, err := outer.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal outer Frame: %v", err)
+	}
+
+	outer2 := new(Frame)
+	if err := outer2.UnmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal outer Frame: %v", err)
+	}
+
+	got, err := outer2.DecapsulateFrame()
+	if err != nil {
+		t.Fatalf("failed to decapsulate Frame: %v", err)
+	}
+
+	if want := inner; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected inner Frame:\n- want: %v\n- got: %v", want, got)
+	}
+}
+
+func TestFrameEncapsulateFrameErrors(t *testing.T) {
+	invalid := &Frame{
+		VLAN: []*VLAN{{
+			ID: VLANMax,
+		}},
+	}
+
+	if err := new(Frame).EncapsulateFrame(invalid); err != ErrInvalidVLAN {
+		t.Fatalf("unexpected error for invalid inner Frame: %v != %v", ErrInvalidVLAN, err)
+	}
+
+	if err := invalid.EncapsulateFrame(new(Frame)); err != ErrInvalidVLAN {
+		t.Fatalf("unexpected error for invalid outer Frame: %v != %v", ErrInvalidVLAN, err)
+	}
+
+	notEncapsulated := &Frame{
+		EtherType: EtherTypeIPv4,
+	}
+	if _, err := notEncapsulated.DecapsulateFrame(); err != ErrNotEncapsulated {
+		t.Fatalf("unexpected error for IPv4 Frame: %v != %v", ErrNotEncapsulated, err)
+	}
+
+	short := &Frame{
+		EtherType: EtherTypeTransparentBridging,
+		Payload:   []byte{0},
+	}
+	if _, err := short.DecapsulateFrame(); err != io.ErrUnexpectedEOF {
+		t.Fatalf("unexpected error for short payload: %v != %v", io.ErrUnexpectedEOF, err)
+	}
+}
+
+func TestFrameInnerFrame(t *testing.T) {
+	innermost := &Frame{
+		Destination: net.HardwareAddr{0, 1, 0, 1, 0, 1},
+		Source:      net.HardwareAddr{1, 0, 1, 0, 1, 0},
+		VLAN: []*VLAN{{
+			ID: 101,
+		}},
+		EtherType: EtherTypeIPv4,
+		Payload:   bytes.Repeat([]byte{0xff}, 50),
+	}
+
+	// nest encapsulates innermost in n layers, alternating between
+	// transparent bridging and I-TAG encapsulation.
+	nest := func(n int) *Frame {
+		f := innermost
+		for i := 0; i < n; i++ {
+			outer := &Frame{
+				Destination: net.HardwareAddr{0, 2, 0, 2, 0, 2},
+				Source:      net.HardwareAddr{2, 0, 2, 0, 2, 0},
+			}
+
+			var err error
+			if i%2 == 0 {
+				err = outer.EncapsulateFrame(f)
+			} else {
+				err = outer.EncapsulateITag(&ITag{ISID: uint32(i)}, f)
+			}
+			if err != nil {
+				t.Fatalf("failed to encapsulate Frame: %v", err)
+			}
+
+			f = outer
+		}
+
+		return f
+	}
+
+	var tests = []struct {
+		desc string
+		f    *Frame
+		ok   bool
+	}{
+		{
+			desc: "not encapsulated",
+			f:    nest(0),
+		},
+		{
+			desc: "short payload",
+			f: &Frame{
+				EtherType: EtherTypeTransparentBridging,
+				Payload:   []byte{0},
+			},
+		},
+		{
+			desc: "short I-TAG",
+			f: &Frame{
+				EtherType: EtherTypeITag,
+				Payload:   []byte{0},
+			},
+		},
+		{
+			desc: "1 layer",
+			f:    nest(1),
+			ok:   true,
+		},
+		{
+			desc: "2 layers",
+			f:    nest(2),
+			ok:   true,
+		},
+		{
+			desc: "maximum layers",
+			f:    nest(MaxTunnelDepth),
+			ok:   true,
+		},
+		{
+			desc: "too many layers",
+			f:    nest(MaxTunnelDepth + 1),
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			inner, ok := tt.f.InnerFrame()
+			if want, got := tt.ok, ok; want != got {
+				t.Fatalf("[%02d] test %q, unexpected ok: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if !ok {
+				if inner != nil {
+					t.Fatalf("[%02d] test %q, unexpected non-nil Frame: %v",
+						i, tt.desc, inner)
+				}
+
+				return
+			}
+
+			if !innermost.Equal(inner) {
+				t.Fatalf("[%02d] test %q, unexpected inner Frame:\n- want: %v\n- got: %v",
+					i, tt.desc, innermost, inner)
+			}
+		})
+	}
+}
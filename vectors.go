@@ -0,0 +1,168 @@
+package ethernet
+
+import (
+	"net"
+)
+
+// A TestVector is a known-good pairing of a Frame and its binary form.
+// TestVectors may be used by other packages to verify that their own
+// Ethernet encoders and decoders interoperate with this package.
+type TestVector struct {
+	// Name is a short, human readable description of the TestVector.
+	Name string
+
+	// Bytes is the binary form of Frame.
+	Bytes []byte
+
+	// Frame is the decoded form of Bytes.
+	Frame *Frame
+
+	// FCS indicates if Bytes ends with a 4-byte IEEE CRC32 frame check
+	// sequence, in which case Frame.MarshalFCS and Frame.UnmarshalFCS
+	// should be used instead of Frame.MarshalBinary and Frame.UnmarshalBinary.
+	FCS bool
+}
+
+// TestVectors is a curated set of canonical Frames and their binary forms,
+// covering untagged, 802.1Q tagged, and Q-in-Q double tagged frames, with
+// the standard IEEE 802.1ad and legacy outer tag protocol identifiers, and
+// with and without a frame check sequence.
+//
+// Every payload is at least 46 bytes in length, so no padding is applied
+// when a Frame is marshaled, and each Frame round-trips exactly.
+//
+// TestVectors must be treated as read-only.
+var TestVectors = []TestVector{
+	{
+		Name: "IPv4, untagged",
+		Bytes: append([]byte{
+			0x00, 0x16, 0x3e, 0x11, 0x22, 0x33,
+			0x00, 0x16, 0x3e, 0x44, 0x55, 0x66,
+			0x08, 0x00,
+		}, vectorPayload(46)...),
+		Frame: &Frame{
+			Destination: net.HardwareAddr{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33},
+			Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+			EtherType:   EtherTypeIPv4,
+			Payload:     vectorPayload(46),
+		},
+	},
+	{
+		Name: "ARP, untagged, broadcast",
+		Bytes: append([]byte{
+			0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+			0x00, 0x16, 0x3e, 0x44, 0x55, 0x66,
+			0x08, 0x06,
+		}, vectorPayload(46)...),
+		Frame: &Frame{
+			Destination: Broadcast,
+			Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+			EtherType:   EtherTypeARP,
+			Payload:     vectorPayload(46),
+		},
+	},
+	{
+		Name: "IPv6, 802.1Q tagged: PRI 5, ID 100",
+		Bytes: append([]byte{
+			0x00, 0x16, 0x3e, 0x11, 0x22, 0x33,
+			0x00, 0x16, 0x3e, 0x44, 0x55, 0x66,
+			0x81, 0x00,
+			0xa0, 0x64,
+			0x86, 0xdd,
+		}, vectorPayload(46)...),
+		Frame: &Frame{
+			Destination: net.HardwareAddr{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33},
+			Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+			VLAN: []*VLAN{{
+				Priority: PriorityVoice,
+				ID:       100,
+			}},
+			EtherType: EtherTypeIPv6,
+			Payload:   vectorPayload(46),
+		},
+	},
+	{
+		Name: "IPv4, 802.1ad Q-in-Q tagged: (TPID 0x88a8, PRI 0, DROP, ID 10), (PRI 3, ID 4094)",
+		Bytes: append([]byte{
+			0x00, 0x16, 0x3e, 0x11, 0x22, 0x33,
+			0x00, 0x16, 0x3e, 0x44, 0x55, 0x66,
+			0x88, 0xa8,
+			0x10, 0x0a,
+			0x81, 0x00,
+			0x6f, 0xfe,
+			0x08, 0x00,
+		}, vectorPayload(46)...),
+		Frame: &Frame{
+			Destination: net.HardwareAddr{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33},
+			Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+			VLAN: []*VLAN{
+				{
+					DropEligible: true,
+					ID:           10,
+					TPID:         EtherTypeServiceVLAN,
+				},
+				{
+					Priority: PriorityCriticalApplications,
+					ID:       4094,
+				},
+			},
+			EtherType: EtherTypeIPv4,
+			Payload:   vectorPayload(46),
+		},
+	},
+	{
+		Name: "IPv4, legacy Q-in-Q tagged: (TPID 0x9100, ID 200), (ID 300)",
+		Bytes: append([]byte{
+			0x00, 0x16, 0x3e, 0x11, 0x22, 0x33,
+			0x00, 0x16, 0x3e, 0x44, 0x55, 0x66,
+			0x91, 0x00,
+			0x00, 0xc8,
+			0x81, 0x00,
+			0x01, 0x2c,
+			0x08, 0x00,
+		}, vectorPayload(46)...),
+		Frame: &Frame{
+			Destination: net.HardwareAddr{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33},
+			Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+			VLAN: []*VLAN{
+				{
+					ID:   200,
+					TPID: EtherTypeQinQ,
+				},
+				{
+					ID: 300,
+				},
+			},
+			EtherType: EtherTypeIPv4,
+			Payload:   vectorPayload(46),
+		},
+	},
+	{
+		Name: "IPv4, untagged, FCS",
+		Bytes: append(append([]byte{
+			0x00, 0x16, 0x3e, 0x11, 0x22, 0x33,
+			0x00, 0x16, 0x3e, 0x44, 0x55, 0x66,
+			0x08, 0x00,
+		}, vectorPayload(46)...),
+			0x60, 0x35, 0x05, 0x42,
+		),
+		Frame: &Frame{
+			Destination: net.HardwareAddr{0x00, 0x16, 0x3e, 0x11, 0x22, 0x33},
+			Source:      net.HardwareAddr{0x00, 0x16, 0x3e, 0x44, 0x55, 0x66},
+			EtherType:   EtherTypeIPv4,
+			Payload:     vectorPayload(46),
+		},
+		FCS: true,
+	},
+}
+
+// vectorPayload returns a payload of length n containing the byte sequence
+// 0, 1, 2, and so on.
+func vectorPayload(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(i)
+	}
+
+	return b
+}
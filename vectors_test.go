@@ -0,0 +1,51 @@
+package ethernet
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestTestVectorsMarshal(t *testing.T) {
+	for i, tt := range TestVectors {
+		t.Run(tt.Name, func(t *testing.T) {
+			marshal := tt.Frame.MarshalBinary
+			if tt.FCS {
+				marshal = tt.Frame.MarshalFCS
+			}
+
+			b, err := marshal()
+			if err != nil {
+				t.Fatalf("[%02d] test %q, unexpected error: %v",
+					i, tt.Name, err)
+			}
+
+			if want, got := tt.Bytes, b; !bytes.Equal(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected Frame bytes:\n- want: %v\n- got: %v",
+					i, tt.Name, want, got)
+			}
+		})
+	}
+}
+
+func TestTestVectorsUnmarshal(t *testing.T) {
+	for i, tt := range TestVectors {
+		t.Run(tt.Name, func(t *testing.T) {
+			f := new(Frame)
+			unmarshal := f.UnmarshalBinary
+			if tt.FCS {
+				unmarshal = f.UnmarshalFCS
+			}
+
+			if err := unmarshal(tt.Bytes); err != nil {
+				t.Fatalf("[%02d] test %q, unexpected error: %v",
+					i, tt.Name, err)
+			}
+
+			if want, got := tt.Frame, f; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected Frame:\n- want: %v\n- got: %v",
+					i, tt.Name, want, got)
+			}
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package ethernet
 
 import (
+	"encoding"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"io"
+	"strconv"
+	"strings"
 )
 
 const (
@@ -24,6 +28,21 @@ var (
 	//   - Priority of greater than 7 is detected
 	//   - ID of greater than 4094 (0xffe) is detected
 	ErrInvalidVLAN = errors.New("invalid VLAN")
+
+	// ErrNoVLAN is returned when an operation requires a Frame to carry one
+	// or more VLAN tags, but none are present.
+	ErrNoVLAN = errors.New("no VLAN tags present")
+
+	// ErrVLANPresent is returned by Frame.SetPriorityTag when a Frame already
+	// carries a VLAN tag which is not a priority tag.
+	ErrVLANPresent = errors.New("VLAN tag already present")
+)
+
+// Compile-time assertions that VLAN implements the binary encoding
+// interfaces.
+var (
+	_ encoding.BinaryMarshaler   = (*VLAN)(nil)
+	_ encoding.BinaryUnmarshaler = (*VLAN)(nil)
 )
 
 // Priority is an IEEE P802.1p priority level. Priority can be any value from
@@ -64,6 +83,14 @@ type VLAN struct {
 	// If ID is 0 (0x000, VLANNone), no VLAN is specified, and the other fields
 	// simply indicate a Frame's priority
 	ID uint16
+
+	// TPID specifies the tag protocol identifier which precedes this VLAN
+	// tag in a Frame. If TPID is 0, EtherTypeVLAN (0x8100) is used.
+	//
+	// When a Frame is unmarshaled, TPID is left 0 for tags which use
+	// EtherTypeVLAN, and is set to the actual identifier for any other tag,
+	// such as EtherTypeQinQ (0x9100), so that the Frame round-trips exactly.
+	TPID EtherType
 }
 
 // MarshalBinary allocates a byte slice and marshals a VLAN into binary form.
@@ -134,3 +161,189 @@ func (v *VLAN) UnmarshalBinary(b []byte) error {
 
 	return nil
 }
+
+// tpid returns the tag protocol identifier used when marshaling a VLAN.
+func (v *VLAN) tpid() EtherType {
+	if v.TPID == 0 {
+		return EtherTypeVLAN
+	}
+
+	return v.TPID
+}
+
+// setTPID sets the TPID field of an unmarshaled VLAN, leaving it 0 for the
+// standard EtherTypeVLAN identifier.
+func (v *VLAN) setTPID(tpid EtherType) {
+	if tpid == EtherTypeVLAN {
+		tpid = 0
+	}
+
+	v.TPID = tpid
+}
+
+// Clone returns a new VLAN with the same field values as v, so that the
+// copy may be modified without affecting v. If v is nil, Clone returns nil.
+func (v *VLAN) Clone() *VLAN {
+	if v == nil {
+		return nil
+	}
+
+	c := *v
+	return &c
+}
+
+// RemarkPriority sets the IEEE 802.1p priority of a Frame's outermost VLAN
+// tag to pcp, as a switch does when enforcing a QoS policy at a boundary.
+// Any inner tags are not modified.
+//
+// If the Frame carries no VLAN tags, ErrNoVLAN is returned. If pcp is too
+// large (greater than 7), ErrInvalidVLAN is returned.
+func (f *Frame) RemarkPriority(pcp uint8) error {
+	return f.remarkPriority(pcp, false)
+}
+
+// RemarkPriorityAll is like RemarkPriority, but sets the priority of every
+// VLAN tag carried by a Frame.
+func (f *Frame) RemarkPriorityAll(pcp uint8) error {
+	return f.remarkPriority(pcp, true)
+}
+
+// remarkPriority implements RemarkPriority and RemarkPriorityAll.
+func (f *Frame) remarkPriority(pcp uint8, all bool) error {
+	if len(f.VLAN) == 0 {
+		return ErrNoVLAN
+	}
+	if Priority(pcp) > PriorityNetworkControl {
+		return ErrInvalidVLAN
+	}
+
+	vlans := f.VLAN[:1]
+	if all {
+		vlans = f.VLAN
+	}
+
+	for _, v := range vlans {
+		v.Priority = Priority(pcp)
+	}
+
+	return nil
+}
+
+// SetPriorityTag sets the IEEE 802.1p priority of a Frame to pcp using a
+// priority tag: a single VLAN tag with ID VLANNone (0), which carries only a
+// priority, and leaves the Frame in its port's native VLAN.
+//
+// If the Frame is untagged, a priority tag is added. If the Frame's
+// outermost tag is already a priority tag, its priority is updated, and any
+// other fields are not modified. If the outermost tag has any other VLAN ID,
+// ErrVLANPresent is returned and the Frame is not modified; use
+// RemarkPriority to change the priority of such a tag.
+//
+// If pcp is too large (greater than 7), ErrInvalidVLAN is returned.
+func (f *Frame) SetPriorityTag(pcp uint8) error {
+	if Priority(pcp) > PriorityNetworkControl {
+		return ErrInvalidVLAN
+	}
+
+	if len(f.VLAN) == 0 {
+		f.VLAN = []*VLAN{{
+			Priority: Priority(pcp),
+			ID:       VLANNone,
+		}}
+		return nil
+	}
+
+	if f.VLAN[0].ID != VLANNone {
+		return ErrVLANPresent
+	}
+
+	f.VLAN[0].Priority = Priority(pcp)
+	return nil
+}
+
+// DropEligible reports whether any VLAN tag carried by a Frame has its drop
+// eligible indicator (DEI) set, marking the Frame as one which may be dropped
+// preferentially in the presence of congestion. An untagged Frame is never
+// drop eligible.
+func (f *Frame) DropEligible() bool {
+	for _, v := range f.VLAN {
+		if v.DropEligible {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetDropEligible sets the drop eligible indicator (DEI) of every VLAN tag
+// carried by a Frame to dei. If the Frame carries no VLAN tags, ErrNoVLAN is
+// returned.
+func (f *Frame) SetDropEligible(dei bool) error {
+	if len(f.VLAN) == 0 {
+		return ErrNoVLAN
+	}
+
+	for _, v := range f.VLAN {
+		v.DropEligible = dei
+	}
+
+	return nil
+}
+
+// ParseVLANList parses a comma-separated list of VLAN IDs and inclusive
+// ranges of VLAN IDs, such as "100-105,200", and returns each VLAN ID in
+// the order in which it appears. Whitespace around each element is ignored,
+// and an empty string produces an empty list.
+//
+// If the list is malformed, a range is reversed, or a VLAN ID is too large
+// (greater than 4094), an error wrapping ErrInvalidVLAN is returned.
+func ParseVLANList(s string) ([]uint16, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var ids []uint16
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+
+		// Each item is either a single ID, or a range of IDs.
+		lo, hi := item, item
+		if i := strings.Index(item, "-"); i != -1 {
+			lo, hi = strings.TrimSpace(item[:i]), strings.TrimSpace(item[i+1:])
+		}
+
+		first, err := parseVLANID(lo)
+		if err != nil {
+			return nil, err
+		}
+		last, err := parseVLANID(hi)
+		if err != nil {
+			return nil, err
+		}
+
+		if first > last {
+			return nil, fmt.Errorf("%w: reversed range %q", ErrInvalidVLAN, item)
+		}
+
+		for id := first; id <= last; id++ {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+// parseVLANID parses a single decimal VLAN ID for ParseVLANList.
+func parseVLANID(s string) (uint16, error) {
+	id, err := strconv.ParseUint(s, 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("%w: malformed ID %q", ErrInvalidVLAN, s)
+	}
+
+	// Check for VLAN ID in valid range
+	if id >= VLANMax {
+		return 0, fmt.Errorf("%w: ID %d out of range", ErrInvalidVLAN, id)
+	}
+
+	return uint16(id), nil
+}
@@ -2,7 +2,11 @@ package ethernet
 
 import (
 	"bytes"
+	"encoding"
+	"encoding/gob"
+	"errors"
 	"io"
+	"net"
 	"reflect"
 	"testing"
 )
@@ -130,6 +134,46 @@ func TestVLANUnmarshalBinary(t *testing.T) {
 	}
 }
 
+func TestVLANBinaryInterfaces(t *testing.T) {
+	want := &VLAN{
+		Priority:     PriorityVoice,
+		DropEligible: true,
+		ID:           101,
+	}
+
+	var (
+		m encoding.BinaryMarshaler   = want
+		u encoding.BinaryUnmarshaler = new(VLAN)
+	)
+
+	b, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal VLAN: %v", err)
+	}
+	if err := u.UnmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal VLAN: %v", err)
+	}
+
+	if got := u.(*VLAN); !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected VLAN:\n- want: %v\n- got: %v", want, got)
+	}
+
+	// gob uses the binary encoding interfaces when they are implemented.
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(want); err != nil {
+		t.Fatalf("failed to gob encode VLAN: %v", err)
+	}
+
+	got := new(VLAN)
+	if err := gob.NewDecoder(&buf).Decode(got); err != nil {
+		t.Fatalf("failed to gob decode VLAN: %v", err)
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected VLAN:\n- want: %v\n- got: %v", want, got)
+	}
+}
+
 // Benchmarks for VLAN.MarshalBinary
 
 func BenchmarkVLANMarshalBinary(b *testing.B) {
@@ -168,3 +212,370 @@ func BenchmarkVLANUnmarshalBinary(b *testing.B) {
 		}
 	}
 }
+
+func TestParseVLANList(t *testing.T) {
+	var tests = []struct {
+		desc string
+		s    string
+		ids  []uint16
+		err  string
+	}{
+		{
+			desc: "empty",
+			s:    " ",
+		},
+		{
+			desc: "single ID",
+			s:    "100",
+			ids:  []uint16{100},
+		},
+		{
+			desc: "ranges and IDs",
+			s:    "100-103, 200 ,4094, 5 - 6",
+			ids:  []uint16{100, 101, 102, 103, 200, 4094, 5, 6},
+		},
+		{
+			desc: "single ID range",
+			s:    "7-7",
+			ids:  []uint16{7},
+		},
+		{
+			desc: "empty element",
+			s:    "100,,200",
+			err:  `invalid VLAN: malformed ID ""`,
+		},
+		{
+			desc: "not a number",
+			s:    "abc",
+			err:  `invalid VLAN: malformed ID "abc"`,
+		},
+		{
+			desc: "open range",
+			s:    "100-",
+			err:  `invalid VLAN: malformed ID ""`,
+		},
+		{
+			desc: "reversed range",
+			s:    "105-100",
+			err:  `invalid VLAN: reversed range "105-100"`,
+		},
+		{
+			desc: "ID too large",
+			s:    "4000-4095",
+			err:  "invalid VLAN: ID 4095 out of range",
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ids, err := ParseVLANList(tt.s)
+			if err != nil {
+				if !errors.Is(err, ErrInvalidVLAN) {
+					t.Fatalf("[%02d] test %q, error does not wrap ErrInvalidVLAN: %v",
+						i, tt.desc, err)
+				}
+
+				if want, got := tt.err, err.Error(); want != got {
+					t.Fatalf("[%02d] test %q, unexpected error: %q != %q",
+						i, tt.desc, want, got)
+				}
+
+				return
+			}
+			if tt.err != "" {
+				t.Fatalf("[%02d] test %q, expected error %q", i, tt.desc, tt.err)
+			}
+
+			if want, got := tt.ids, ids; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected IDs:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestVLANClone(t *testing.T) {
+	if v := (*VLAN)(nil).Clone(); v != nil {
+		t.Fatalf("expected nil VLAN, but got: %v", v)
+	}
+
+	v := &VLAN{
+		Priority:     PriorityVoice,
+		DropEligible: true,
+		ID:           100,
+	}
+
+	c := v.Clone()
+	if c == v {
+		t.Fatal("Clone returned the same VLAN pointer")
+	}
+	if !reflect.DeepEqual(v, c) {
+		t.Fatalf("unexpected VLAN:\n- want: %v\n- got: %v", v, c)
+	}
+
+	c.ID = 200
+	if want, got := uint16(100), v.ID; want != got {
+		t.Fatalf("original VLAN was modified: %v != %v", want, got)
+	}
+}
+
+func TestFrameRemarkPriority(t *testing.T) {
+	var tests = []struct {
+		desc string
+		vlan []*VLAN
+		pcp  uint8
+		all  bool
+		out  []*VLAN
+		err  error
+	}{
+		{
+			desc: "no VLANs",
+			pcp:  uint8(PriorityVoice),
+			err:  ErrNoVLAN,
+		},
+		{
+			desc: "priority too large",
+			vlan: []*VLAN{{ID: 10}},
+			pcp:  8,
+			out:  []*VLAN{{ID: 10}},
+			err:  ErrInvalidVLAN,
+		},
+		{
+			desc: "single tag",
+			vlan: []*VLAN{{ID: 10, DropEligible: true}},
+			pcp:  uint8(PriorityVoice),
+			out:  []*VLAN{{Priority: PriorityVoice, ID: 10, DropEligible: true}},
+		},
+		{
+			desc: "stacked tags, outer only",
+			vlan: []*VLAN{{ID: 10}, {Priority: PriorityVideo, ID: 20}},
+			pcp:  uint8(PriorityNetworkControl),
+			out: []*VLAN{
+				{Priority: PriorityNetworkControl, ID: 10},
+				{Priority: PriorityVideo, ID: 20},
+			},
+		},
+		{
+			desc: "stacked tags, all",
+			vlan: []*VLAN{{ID: 10}, {Priority: PriorityVideo, ID: 20}},
+			pcp:  uint8(PriorityBackground),
+			all:  true,
+			out: []*VLAN{
+				{Priority: PriorityBackground, ID: 10},
+				{Priority: PriorityBackground, ID: 20},
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f := &Frame{VLAN: tt.vlan}
+
+			remark := f.RemarkPriority
+			if tt.all {
+				remark = f.RemarkPriorityAll
+			}
+
+			if want, got := tt.err, remark(tt.pcp); want != got {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.out, f.VLAN; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected VLANs:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameDropEligible(t *testing.T) {
+	var tests = []struct {
+		desc string
+		vlan []*VLAN
+		ok   bool
+	}{
+		{
+			desc: "no VLANs",
+		},
+		{
+			desc: "single tag",
+			vlan: []*VLAN{{ID: 10}},
+		},
+		{
+			desc: "single tag, DEI",
+			vlan: []*VLAN{{ID: 10, DropEligible: true}},
+			ok:   true,
+		},
+		{
+			desc: "stacked tags, outer DEI",
+			vlan: []*VLAN{{ID: 10, DropEligible: true}, {ID: 20}},
+			ok:   true,
+		},
+		{
+			desc: "stacked tags, inner DEI",
+			vlan: []*VLAN{{ID: 10}, {ID: 20, DropEligible: true}},
+			ok:   true,
+		},
+		{
+			desc: "stacked tags, no DEI",
+			vlan: []*VLAN{{ID: 10}, {ID: 20}},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f := &Frame{VLAN: tt.vlan}
+			if want, got := tt.ok, f.DropEligible(); want != got {
+				t.Fatalf("[%02d] test %q, unexpected DropEligible: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameSetDropEligible(t *testing.T) {
+	var tests = []struct {
+		desc string
+		vlan []*VLAN
+		dei  bool
+		out  []*VLAN
+		err  error
+	}{
+		{
+			desc: "no VLANs",
+			dei:  true,
+			err:  ErrNoVLAN,
+		},
+		{
+			desc: "mixed tags, set",
+			vlan: []*VLAN{{ID: 10}, {ID: 20, DropEligible: true}, {ID: 30}},
+			dei:  true,
+			out: []*VLAN{
+				{ID: 10, DropEligible: true},
+				{ID: 20, DropEligible: true},
+				{ID: 30, DropEligible: true},
+			},
+		},
+		{
+			desc: "mixed tags, clear",
+			vlan: []*VLAN{{ID: 10, DropEligible: true}, {Priority: PriorityVoice, ID: 20}},
+			out: []*VLAN{
+				{ID: 10},
+				{Priority: PriorityVoice, ID: 20},
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f := &Frame{VLAN: tt.vlan}
+			if want, got := tt.err, f.SetDropEligible(tt.dei); want != got {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.out, f.VLAN; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected VLANs:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.dei && tt.err == nil, f.DropEligible(); want != got {
+				t.Fatalf("[%02d] test %q, unexpected DropEligible: %v != %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameSetPriorityTag(t *testing.T) {
+	var tests = []struct {
+		desc string
+		vlan []*VLAN
+		pcp  uint8
+		out  []*VLAN
+		err  error
+	}{
+		{
+			desc: "priority too large",
+			pcp:  8,
+			err:  ErrInvalidVLAN,
+		},
+		{
+			desc: "untagged",
+			pcp:  uint8(PriorityVoice),
+			out:  []*VLAN{{Priority: PriorityVoice, ID: VLANNone}},
+		},
+		{
+			desc: "untagged, best effort",
+			pcp:  uint8(PriorityBestEffort),
+			out:  []*VLAN{{ID: VLANNone}},
+		},
+		{
+			desc: "priority tagged",
+			vlan: []*VLAN{{Priority: PriorityVideo, ID: VLANNone, DropEligible: true}},
+			pcp:  uint8(PriorityVoice),
+			out:  []*VLAN{{Priority: PriorityVoice, ID: VLANNone, DropEligible: true}},
+		},
+		{
+			desc: "priority tagged, stacked",
+			vlan: []*VLAN{{ID: VLANNone}, {Priority: PriorityVideo, ID: 20}},
+			pcp:  uint8(PriorityNetworkControl),
+			out: []*VLAN{
+				{Priority: PriorityNetworkControl, ID: VLANNone},
+				{Priority: PriorityVideo, ID: 20},
+			},
+		},
+		{
+			desc: "VLAN tagged",
+			vlan: []*VLAN{{Priority: PriorityVideo, ID: 10}},
+			pcp:  uint8(PriorityVoice),
+			out:  []*VLAN{{Priority: PriorityVideo, ID: 10}},
+			err:  ErrVLANPresent,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			f := &Frame{VLAN: tt.vlan}
+			if want, got := tt.err, f.SetPriorityTag(tt.pcp); want != got {
+				t.Fatalf("[%02d] test %q, unexpected error: %v != %v",
+					i, tt.desc, want, got)
+			}
+
+			if want, got := tt.out, f.VLAN; !reflect.DeepEqual(want, got) {
+				t.Fatalf("[%02d] test %q, unexpected VLANs:\n- want: %v\n- got: %v",
+					i, tt.desc, want, got)
+			}
+		})
+	}
+}
+
+func TestFrameSetPriorityTagRoundTrip(t *testing.T) {
+	f := &Frame{
+		Destination: Broadcast,
+		Source:      net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+		EtherType:   EtherTypeIPv4,
+	}
+	if err := f.SetPriorityTag(uint8(PriorityVoice)); err != nil {
+		t.Fatalf("failed to set priority tag: %v", err)
+	}
+
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to marshal Frame: %v", err)
+	}
+
+	// TPID 0x8100, followed by PCP 5 and VID 0.
+	if want, got := []byte{0x81, 0x00, 0xa0, 0x00}, b[12:16]; !bytes.Equal(want, got) {
+		t.Fatalf("unexpected VLAN tag bytes: %v != %v", want, got)
+	}
+
+	f2 := new(Frame)
+	if err := f2.UnmarshalBinary(b); err != nil {
+		t.Fatalf("failed to unmarshal Frame: %v", err)
+	}
+	if want, got := f.VLAN, f2.VLAN; !reflect.DeepEqual(want, got) {
+		t.Fatalf("unexpected VLANs:\n- want: %v\n- got: %v", want, got)
+	}
+}